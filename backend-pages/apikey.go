@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"pages/internal/auth"
+	"pages/internal/store"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const apiKeyUsage = "usage: pages apikey create [--admin] [--expires <duration>] <name> | list | revoke <key_id>"
+
+// runAPIKey 는 apikey 하위 명령을 실행합니다. 서버 없이 키를 발급, 조회, 폐기할 때 씁니다.
+//
+//	pages apikey create --admin ops   관리 키 발급 (키 원문은 이때 한 번만 출력)
+//	pages apikey list                 발급한 키 목록 출력
+//	pages apikey revoke 3             키 폐기
+func runAPIKey(args []string) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	s := store.NewSQLStore(db)
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		admin := flags.Bool("admin", false, "관리 API 를 쓸 수 있는 키")
+		expires := flags.Duration("expires", 0, "만료까지의 기간 (예: 720h, 0 이면 만료 없음)")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 {
+			return errors.New(apiKeyUsage)
+		}
+
+		var expiresAt *time.Time
+		if *expires > 0 {
+			t := time.Now().Add(*expires)
+			expiresAt = &t
+		}
+		issued, err := auth.IssueAPIKey(ctx, s, flags.Arg(0), *admin, expiresAt)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("key_id %d\n", issued.KeyID)
+		fmt.Printf("key    %s\n", issued.Key)
+		fmt.Println("the key is shown only once; store it now")
+		return nil
+
+	case "list":
+		keys, err := s.ListAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tADMIN\tSTATE\tLAST USED")
+		now := time.Now()
+		for _, k := range keys {
+			state := "active"
+			if k.RevokedAt != nil {
+				state = "revoked"
+			} else if !k.Active(now) {
+				state = "expired"
+			}
+			lastUsed := "-"
+			if k.LastUsedAt != nil {
+				lastUsed = k.LastUsedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\t%s\n", k.KeyID, k.Name, k.Prefix, k.Admin, state, lastUsed)
+		}
+		return w.Flush()
+
+	case "revoke":
+		if len(args) != 2 {
+			return errors.New(apiKeyUsage)
+		}
+		keyID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid key_id %q: %s", args[1], apiKeyUsage)
+		}
+		if err := s.RevokeAPIKey(ctx, keyID); err != nil {
+			return err
+		}
+		fmt.Printf("revoked %d\n", keyID)
+		return nil
+
+	default:
+		return errors.New(apiKeyUsage)
+	}
+}
+
+// bootstrapAPIKey 는 발급한 키가 하나도 없으면(폐기한 키 포함) 관리 키를 발급합니다. 새 배포나 DB_PATH=:memory: 로
+// 띄운 서버는 이 키로 처음 인증합니다. BOOTSTRAP_API_KEY_FILE 이 있으면 키를 그 파일(0600)에 쓰고, 없으면 로그에
+// 한 번 출력합니다.
+func bootstrapAPIKey(keys store.APIKeyStore) error {
+	ctx := context.Background()
+	existing, err := keys.ListAPIKeys(ctx)
+	if err != nil || len(existing) > 0 {
+		return err
+	}
+
+	// 발급한 뒤 쓰지 못해 아무도 모르는 키가 남지 않도록 파일을 먼저 엽니다.
+	path := os.Getenv("BOOTSTRAP_API_KEY_FILE")
+	var file *os.File
+	if path != "" {
+		if file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600); err != nil {
+			return fmt.Errorf("failed to open BOOTSTRAP_API_KEY_FILE: %w", err)
+		}
+		defer file.Close()
+	}
+
+	issued, err := auth.IssueAPIKey(ctx, keys, "bootstrap", true, nil)
+	if err != nil {
+		return err
+	}
+	if file == nil {
+		log.Printf("Issued bootstrap admin API key %d (shown only once): %s", issued.KeyID, issued.Key)
+		return nil
+	}
+	if _, err := fmt.Fprintln(file, issued.Key); err != nil {
+		return fmt.Errorf("failed to write BOOTSTRAP_API_KEY_FILE: %w", err)
+	}
+	log.Printf("Issued bootstrap admin API key %d to %s", issued.KeyID, path)
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"pages/internal/export"
+	"pages/internal/store"
+)
+
+// runExportStatic 는 export-static 하위 명령을 실행합니다.
+//
+//	pages export-static --site cloud --out ./dist [--base-url https://cloud.example.com]
+func runExportStatic(args []string) error {
+	flags := flag.NewFlagSet("export-static", flag.ContinueOnError)
+	siteCode := flags.String("site", "", "site code")
+	out := flags.String("out", "./dist", "output directory")
+	baseURL := flags.String("base-url", "", "sitemap base URL (default https://<site domain>)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *siteCode == "" {
+		return fmt.Errorf("usage: pages export-static --site <code> [--out dir] [--base-url url]")
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	s := store.NewSQLStore(db)
+	result, err := export.New(s, s).Export(context.Background(), *siteCode, *out, *baseURL)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range result.Written {
+		fmt.Printf("written  %s\n", name)
+	}
+	for _, name := range result.Removed {
+		fmt.Printf("removed  %s\n", name)
+	}
+	for _, path := range result.Skipped {
+		fmt.Printf("skipped  %s (slug cannot be used as a file path)\n", path)
+	}
+	fmt.Printf("%d written, %d unchanged, %d removed\n", len(result.Written), len(result.Unchanged), len(result.Removed))
+	return nil
+}
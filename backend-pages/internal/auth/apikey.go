@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"pages/internal/models"
+	"pages/internal/store"
+	"time"
+)
+
+// APIKeyPrefix 는 API 키의 접두사입니다. Bearer 토큰이 이 접두사로 시작하면 JWT 가 아닌 API 키로 봅니다.
+const APIKeyPrefix = "pk_"
+
+// prefixLength 는 목록에서 키를 구분하도록 저장하는 키 앞부분의 길이입니다(접두사 포함).
+const prefixLength = len(APIKeyPrefix) + 8
+
+// GenerateAPIKey 는 256비트 난수로 새 키를 만들어 키, 목록에 보일 앞부분과 저장할 해시를 반환합니다.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:prefixLength], HashAPIKey(key), nil
+}
+
+// HashAPIKey 는 키를 저장, 조회할 때 쓰는 SHA-256 해시(hex)입니다. 키가 충분히 긴 난수이므로
+// 비밀번호처럼 느린 해시를 쓰지 않아도 원문을 알아낼 수 없습니다.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IssueAPIKey 는 새 키를 만들어 해시를 저장하고, 키 원문을 담은 발급 결과를 반환합니다.
+// 원문은 저장하지 않으므로 이 결과에서만 볼 수 있습니다.
+func IssueAPIKey(ctx context.Context, keys store.APIKeyStore, name string, admin bool, expiresAt *time.Time) (*models.IssuedAPIKey, error) {
+	key, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	id, err := keys.CreateAPIKey(ctx, &models.APIKey{Name: name, Prefix: prefix, Admin: admin, ExpiresAt: expiresAt}, hash)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := keys.GetAPIKey(ctx, int(id))
+	if err != nil {
+		return nil, err
+	}
+	return &models.IssuedAPIKey{APIKey: *saved, Key: key}, nil
+}
@@ -0,0 +1,136 @@
+// Package auth 는 API 요청을 API 키나 JWT bearer 토큰으로 인증합니다.
+//
+// 자격 증명은 Authorization: Bearer <토큰> 이나 X-API-Key: <키> 헤더로 보냅니다. 토큰이 pk_ 로
+// 시작하면 api_keys 에 저장된 해시와 비교하는 API 키로, 그 밖에는 HS256/RS256 JWT 로 검증합니다.
+// 인증한 요청의 Principal 은 FromContext 로 꺼냅니다.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"pages/internal/store"
+	"pages/pkg/response"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoCredentials 는 요청에 자격 증명이 없을 때 반환됩니다.
+	ErrNoCredentials = errors.New("auth: no credentials")
+	// ErrInvalidCredentials 는 키나 토큰이 없거나 폐기, 만료되었거나 검증에 실패했을 때 반환됩니다.
+	ErrInvalidCredentials = errors.New("auth: invalid credentials")
+)
+
+// 인증 방식
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Principal 은 인증한 요청의 주체입니다. Subject 는 API 키이면 "apikey:<key_id>", JWT 이면 sub 클레임입니다.
+type Principal struct {
+	Subject  string `json:"subject"`
+	Name     string `json:"name"`
+	Method   string `json:"method"`
+	APIKeyID int    `json:"api_key_id,omitempty"`
+	Admin    bool   `json:"admin"`
+}
+
+type contextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext 는 Middleware 가 저장한 Principal 을 반환합니다. 인증하지 않은 요청이면 nil 입니다.
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(contextKey{}).(*Principal)
+	return principal
+}
+
+// touchInterval 은 API 키의 마지막 사용 시각을 갱신하는 최소 간격입니다. 요청마다 쓰지 않도록 합니다.
+const touchInterval = time.Minute
+
+type Authenticator struct {
+	keys store.APIKeyStore
+	jwt  *JWTVerifier // nil 이면 JWT 를 받지 않습니다.
+}
+
+func New(keys store.APIKeyStore, jwt *JWTVerifier) *Authenticator {
+	return &Authenticator{keys: keys, jwt: jwt}
+}
+
+// Authenticate 는 요청의 자격 증명을 검증해 Principal 을 반환합니다.
+func (a *Authenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token := strings.TrimSpace(r.Header.Get("X-API-Key"))
+	if token == "" {
+		scheme, value, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			token = strings.TrimSpace(value)
+		}
+	}
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+
+	if strings.HasPrefix(token, APIKeyPrefix) {
+		return a.apiKey(r.Context(), token)
+	}
+	if a.jwt == nil {
+		return nil, ErrInvalidCredentials
+	}
+	claims, err := a.jwt.Verify(token, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	return &Principal{Subject: claims.Subject, Name: claims.Name, Method: MethodJWT, Admin: claims.Admin}, nil
+}
+
+func (a *Authenticator) apiKey(ctx context.Context, token string) (*Principal, error) {
+	key, err := a.keys.GetAPIKeyByHash(ctx, HashAPIKey(token))
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		return nil, ErrInvalidCredentials
+	}
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > touchInterval {
+		// 사용 시각은 참고용이므로 갱신에 실패해도 요청은 처리합니다.
+		if err := a.keys.TouchAPIKey(ctx, key.KeyID); err != nil {
+			log.Printf("auth: failed to update last_used_at of api key %d: %v", key.KeyID, err)
+		}
+	}
+
+	return &Principal{
+		Subject:  fmt.Sprintf("apikey:%d", key.KeyID),
+		Name:     key.Name,
+		Method:   MethodAPIKey,
+		APIKeyID: key.KeyID,
+		Admin:    key.Admin,
+	}, nil
+}
+
+// Middleware 는 인증하지 못한 요청에 401 UNAUTHORIZED 로 응답하고, 인증한 요청은 Principal 을 컨텍스트에 담아 넘깁니다.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) || errors.Is(err, ErrInvalidCredentials) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="pages"`)
+			response.Error(w, r, response.NewError(http.StatusUnauthorized, response.CodeUnauthorized))
+			return
+		}
+		if err != nil {
+			response.Error(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
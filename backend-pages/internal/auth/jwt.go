@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken 은 JWT 의 형식, 서명이나 클레임이 올바르지 않을 때 반환됩니다.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// leeway 는 서버 사이 시계 차이를 감안해 exp, nbf 를 검사할 때 허용하는 오차입니다.
+const leeway = time.Minute
+
+// minSecretLength 는 HS256 공유 비밀의 최소 길이(바이트)입니다. 서명 키로 충분한 엔트로피를 갖도록 합니다.
+const minSecretLength = 32
+
+// JWTConfig 는 JWT 검증 설정입니다. 설정한 키의 알고리즘(HS256, RS256)만 허용하므로
+// 공개 키를 HMAC 비밀로 쓰는 알고리즘 혼동 공격이나 alg=none 토큰은 거부됩니다.
+type JWTConfig struct {
+	HS256Secret []byte
+	RS256Key    *rsa.PublicKey
+	Issuer      string // 비어 있지 않으면 iss 가 같아야 합니다.
+	Audience    string // 비어 있지 않으면 aud 에 있어야 합니다.
+}
+
+// Claims 는 검증한 토큰에서 쓰는 클레임입니다. sub 와 exp 는 반드시 있어야 합니다.
+// admin 이 true 인 토큰은 관리 API(/api/admin)를 쓸 수 있습니다.
+type Claims struct {
+	Subject   string
+	Name      string
+	Admin     bool
+	ExpiresAt time.Time
+}
+
+type JWTVerifier struct {
+	config JWTConfig
+}
+
+func NewJWTVerifier(config JWTConfig) *JWTVerifier {
+	return &JWTVerifier{config: config}
+}
+
+// LoadJWTVerifier 는 환경 변수에 지정한 파일에서 키를 읽어 검증기를 만듭니다. 키가 하나도 없으면
+// nil 을 반환하며, 이때는 JWT 를 받지 않고 API 키로만 인증합니다.
+//
+//	JWT_HS256_SECRET_FILE      HS256 공유 비밀 (앞뒤 공백을 뺀 32바이트 이상)
+//	JWT_RS256_PUBLIC_KEY_FILE  RS256 공개 키 PEM (PUBLIC KEY, RSA PUBLIC KEY 또는 CERTIFICATE)
+//	JWT_ISSUER, JWT_AUDIENCE   iss, aud 검사 (선택)
+func LoadJWTVerifier() (*JWTVerifier, error) {
+	config := JWTConfig{
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+	}
+
+	if path := os.Getenv("JWT_HS256_SECRET_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT_HS256_SECRET_FILE: %w", err)
+		}
+		config.HS256Secret = []byte(strings.TrimSpace(string(data)))
+		if len(config.HS256Secret) < minSecretLength {
+			return nil, fmt.Errorf("JWT_HS256_SECRET_FILE: secret must be at least %d bytes", minSecretLength)
+		}
+	}
+
+	if path := os.Getenv("JWT_RS256_PUBLIC_KEY_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT_RS256_PUBLIC_KEY_FILE: %w", err)
+		}
+		if config.RS256Key, err = parseRSAPublicKey(data); err != nil {
+			return nil, fmt.Errorf("JWT_RS256_PUBLIC_KEY_FILE: %w", err)
+		}
+	}
+
+	if config.HS256Secret == nil && config.RS256Key == nil {
+		return nil, nil
+	}
+	return NewJWTVerifier(config), nil
+}
+
+func parseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	if rsaKey.N.BitLen() < 2048 {
+		return nil, errors.New("RSA key must be at least 2048 bits")
+	}
+	return rsaKey, nil
+}
+
+// audience 는 문자열 하나나 문자열 배열인 aud 클레임입니다.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, v := range a {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify 는 token 의 서명과 클레임(exp, nbf, iss, aud)을 검사합니다. 실패하면 ErrInvalidToken 을 감싼 오류를 반환합니다.
+func (v *JWTVerifier) Verify(token string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	if err := v.verifySignature(header.Alg, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims struct {
+		Subject   string   `json:"sub"`
+		Name      string   `json:"name"`
+		Admin     bool     `json:"admin"`
+		Issuer    string   `json:"iss"`
+		Audience  audience `json:"aud"`
+		ExpiresAt *float64 `json:"exp"`
+		NotBefore *float64 `json:"nbf"`
+	}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+
+	switch {
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	case claims.ExpiresAt == nil:
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	case now.After(unixTime(*claims.ExpiresAt).Add(leeway)):
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	case claims.NotBefore != nil && now.Add(leeway).Before(unixTime(*claims.NotBefore)):
+		return nil, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	case v.config.Issuer != "" && claims.Issuer != v.config.Issuer:
+		return nil, fmt.Errorf("%w: unexpected iss", ErrInvalidToken)
+	case v.config.Audience != "" && !claims.Audience.contains(v.config.Audience):
+		return nil, fmt.Errorf("%w: unexpected aud", ErrInvalidToken)
+	}
+
+	return &Claims{
+		Subject:   claims.Subject,
+		Name:      claims.Name,
+		Admin:     claims.Admin,
+		ExpiresAt: unixTime(*claims.ExpiresAt),
+	}, nil
+}
+
+func (v *JWTVerifier) verifySignature(alg, signed string, signature []byte) error {
+	switch {
+	case alg == "HS256" && v.config.HS256Secret != nil:
+		mac := hmac.New(sha256.New, v.config.HS256Secret)
+		mac.Write([]byte(signed))
+		if hmac.Equal(signature, mac.Sum(nil)) {
+			return nil
+		}
+	case alg == "RS256" && v.config.RS256Key != nil:
+		digest := sha256.Sum256([]byte(signed))
+		if rsa.VerifyPKCS1v15(v.config.RS256Key, crypto.SHA256, digest[:], signature) == nil {
+			return nil
+		}
+	default:
+		return fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, alg)
+	}
+	return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func unixTime(seconds float64) time.Time {
+	return time.Unix(int64(seconds), 0)
+}
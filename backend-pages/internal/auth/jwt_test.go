@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// signToken 은 header 의 alg 로 claims 를 서명한 토큰을 만듭니다. key 는 HS256 이면 []byte,
+// RS256 이면 *rsa.PrivateKey 이며, 그 밖의 alg 는 빈 서명을 붙입니다.
+func signToken(t *testing.T, alg string, claims map[string]interface{}, key interface{}) string {
+	t.Helper()
+	segment := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := segment(map[string]string{"alg": alg, "typ": "JWT"}) + "." + segment(claims)
+
+	var signature []byte
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case "RS256":
+		digest := sha256.Sum256([]byte(signed))
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	claims := func(extra map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{"sub": "user-1", "exp": now.Add(time.Hour).Unix()}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+
+	hs := NewJWTVerifier(JWTConfig{HS256Secret: testSecret})
+	rs := NewJWTVerifier(JWTConfig{RS256Key: &rsaKey.PublicKey})
+	aud := NewJWTVerifier(JWTConfig{HS256Secret: testSecret, Issuer: "issuer", Audience: "pages"})
+
+	tests := []struct {
+		name     string
+		verifier *JWTVerifier
+		token    string
+		ok       bool
+	}{
+		{"hs256", hs, signToken(t, "HS256", claims(nil), testSecret), true},
+		{"rs256", rs, signToken(t, "RS256", claims(nil), rsaKey), true},
+		{"wrong secret", hs, signToken(t, "HS256", claims(nil), []byte("another secret of thirty-two bytes")), false},
+		{"alg none on hs256 verifier", hs, signToken(t, "none", claims(nil), nil), false},
+		{"alg none on rs256 verifier", rs, signToken(t, "none", claims(nil), nil), false},
+		// 공개 키를 HMAC 비밀로 서명한 토큰은 RS256 만 설정한 검증기가 거부해야 합니다.
+		{"hs256 signed with rsa public key", rs, signToken(t, "HS256", claims(nil), publicPEM), false},
+		{"hs256 signed with rsa public key der", rs, signToken(t, "HS256", claims(nil), der), false},
+		{"rs256 on hs256 verifier", hs, signToken(t, "RS256", claims(nil), rsaKey), false},
+		{"missing sub", hs, signToken(t, "HS256", map[string]interface{}{"exp": now.Add(time.Hour).Unix()}, testSecret), false},
+		{"missing exp", hs, signToken(t, "HS256", map[string]interface{}{"sub": "user-1"}, testSecret), false},
+		{"expired", hs, signToken(t, "HS256", claims(map[string]interface{}{"exp": now.Add(-2 * time.Minute).Unix()}), testSecret), false},
+		{"expired within leeway", hs, signToken(t, "HS256", claims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()}), testSecret), true},
+		{"not valid yet", hs, signToken(t, "HS256", claims(map[string]interface{}{"nbf": now.Add(2 * time.Minute).Unix()}), testSecret), false},
+		{"not valid yet within leeway", hs, signToken(t, "HS256", claims(map[string]interface{}{"nbf": now.Add(30 * time.Second).Unix()}), testSecret), true},
+		{"aud string", aud, signToken(t, "HS256", claims(map[string]interface{}{"iss": "issuer", "aud": "pages"}), testSecret), true},
+		{"aud array", aud, signToken(t, "HS256", claims(map[string]interface{}{"iss": "issuer", "aud": []string{"other", "pages"}}), testSecret), true},
+		{"aud string mismatch", aud, signToken(t, "HS256", claims(map[string]interface{}{"iss": "issuer", "aud": "other"}), testSecret), false},
+		{"aud array mismatch", aud, signToken(t, "HS256", claims(map[string]interface{}{"iss": "issuer", "aud": []string{"other"}}), testSecret), false},
+		{"aud missing", aud, signToken(t, "HS256", claims(map[string]interface{}{"iss": "issuer"}), testSecret), false},
+		{"aud wrong type", aud, signToken(t, "HS256", claims(map[string]interface{}{"iss": "issuer", "aud": 1}), testSecret), false},
+		{"iss mismatch", aud, signToken(t, "HS256", claims(map[string]interface{}{"iss": "other", "aud": "pages"}), testSecret), false},
+		{"malformed", hs, "not.a-token", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.verifier.Verify(tt.token, now)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("Verify() error = %v, want nil", err)
+				}
+				if got.Subject != "user-1" {
+					t.Errorf("Subject = %q, want user-1", got.Subject)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Verify() error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
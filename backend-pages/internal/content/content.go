@@ -0,0 +1,160 @@
+package content
+
+import (
+	"bytes"
+	"container/list"
+	"html/template"
+	"pages/internal/models"
+	"strings"
+	"sync"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	nethtml "golang.org/x/net/html"
+)
+
+// Markdown 안의 HTML 도 결과를 정제하므로 그대로 통과시킵니다.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// ToHTML 은 본문을 format 에 따라 HTML 로 렌더링한 뒤 s 로 정제합니다.
+func ToHTML(format, source string, s *Sanitizer) string {
+	return s.Sanitize(Render(format, source))
+}
+
+// Render 는 본문을 format 에 따라 정제하기 전의 HTML 로 렌더링합니다.
+// markdown 은 GFM 으로 변환하고, plain 은 이스케이프한 뒤 빈 줄을 문단, 줄바꿈을 <br> 로 바꿉니다.
+// 알 수 없는 형식은 html 로 취급합니다.
+func Render(format, source string) string {
+	switch format {
+	case models.ContentFormatMarkdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(source), &buf); err != nil {
+			// goldmark 는 bytes.Buffer 쓰기 외에는 실패하지 않습니다.
+			return ""
+		}
+		return buf.String()
+	case models.ContentFormatPlain:
+		return plainToHTML(source)
+	default:
+		return source
+	}
+}
+
+func plainToHTML(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	var b strings.Builder
+	for _, para := range strings.Split(source, "\n\n") {
+		para = strings.Trim(para, "\n")
+		if para == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(template.HTMLEscapeString(para), "\n", "<br>"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// Key 는 렌더링 캐시의 키입니다. 리비전은 기록 후 바뀌지 않으므로 (페이지, 리비전, 정책) 으로 충분합니다.
+type Key struct {
+	PageID   int
+	Revision int
+	Policy   string // Sanitizer 의 정책 해시
+}
+
+// Renderer 는 ToHTML 결과를 리비전 단위로 캐시합니다. 가장 오래 쓰이지 않은 항목부터 버립니다.
+type Renderer struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // 앞쪽이 최근에 쓰인 항목
+	entries map[Key]*list.Element
+}
+
+type entry struct {
+	key  Key
+	html string
+}
+
+// NewRenderer 는 최대 size 개의 리비전을 캐시하는 Renderer 를 생성합니다.
+func NewRenderer(size int) *Renderer {
+	return &Renderer{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[Key]*list.Element),
+	}
+}
+
+// Page 는 page 의 Revision 을 s 로 정제한 렌더링 결과를 반환하고, 없으면 렌더링해 캐시합니다.
+// Revision 이 0 이면(리비전 정보가 없는 경우) 캐시하지 않습니다.
+func (r *Renderer) Page(page *models.Page, s *Sanitizer) string {
+	if page.Revision == 0 {
+		return ToHTML(page.ContentFormat, page.Content, s)
+	}
+	key := Key{PageID: page.PageID, Revision: page.Revision, Policy: s.fingerprint}
+
+	r.mu.Lock()
+	if el, ok := r.entries[key]; ok {
+		r.order.MoveToFront(el)
+		r.mu.Unlock()
+		return el.Value.(*entry).html
+	}
+	r.mu.Unlock()
+
+	out := ToHTML(page.ContentFormat, page.Content, s)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[key]; !ok {
+		r.entries[key] = r.order.PushFront(&entry{key: key, html: out})
+		for r.order.Len() > r.size {
+			oldest := r.order.Back()
+			r.order.Remove(oldest)
+			delete(r.entries, oldest.Value.(*entry).key)
+		}
+	}
+	return out
+}
+
+// Text 는 본문을 렌더링한 HTML 에서 태그를 걷어 낸 텍스트입니다. 공백은 하나로 줄입니다.
+// script, style 안의 내용은 포함하지 않습니다.
+func Text(format, source string) string {
+	var b strings.Builder
+	z := nethtml.NewTokenizer(strings.NewReader(Render(format, source)))
+	skip := 0
+	for {
+		switch z.Next() {
+		case nethtml.ErrorToken:
+			return strings.Join(strings.Fields(b.String()), " ")
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			name, _ := z.TagName()
+			if string(name) == "script" || string(name) == "style" {
+				skip++
+			}
+			if !inlineElements[string(name)] {
+				b.WriteByte(' ')
+			}
+		case nethtml.EndTagToken:
+			name, _ := z.TagName()
+			if (string(name) == "script" || string(name) == "style") && skip > 0 {
+				skip--
+			}
+			if !inlineElements[string(name)] {
+				b.WriteByte(' ')
+			}
+		case nethtml.TextToken:
+			if skip == 0 {
+				b.Write(z.Text())
+			}
+		}
+	}
+}
+
+// inlineElements 는 Text 에서 앞뒤로 공백을 넣지 않는 인라인 요소입니다.
+var inlineElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "code": true, "del": true, "em": true, "i": true, "ins": true,
+	"kbd": true, "mark": true, "s": true, "small": true, "span": true, "strong": true, "sub": true, "sup": true, "u": true,
+}
@@ -0,0 +1,224 @@
+package content
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"pages/internal/models"
+	"pages/internal/store"
+	"sort"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+)
+
+// 정책에 포함할 수 없는 항목. 허용 목록으로 스크립트가 실행될 수 있는 경로를 열지 않도록 막습니다.
+var (
+	forbiddenElements = map[string]bool{
+		"script": true, "style": true, "iframe": true, "frame": true, "frameset": true,
+		"object": true, "embed": true, "applet": true, "base": true, "meta": true, "link": true,
+		"form": true, "svg": true, "math": true,
+	}
+	forbiddenSchemes = map[string]bool{"javascript": true, "vbscript": true, "data": true}
+	// urlAttributes 는 URL 을 값으로 갖는 속성입니다. 값의 스킴을 검사합니다.
+	urlAttributes = map[string]bool{"href": true, "src": true, "cite": true, "poster": true, "longdesc": true}
+)
+
+// DefaultPolicy 는 정책을 저장하지 않은 사이트에 적용하는 기본 허용 목록입니다.
+func DefaultPolicy() models.ContentPolicy {
+	return models.ContentPolicy{
+		Mode: models.PolicyModeSanitize,
+		Elements: []string{
+			"p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "code",
+			"em", "strong", "b", "i", "u", "s", "del", "ins", "sub", "sup", "mark", "small",
+			"ul", "ol", "li", "dl", "dt", "dd", "a", "img", "figure", "figcaption",
+			"table", "thead", "tbody", "tfoot", "tr", "th", "td", "caption", "div", "span",
+		},
+		Attributes: map[string][]string{
+			"*":   {"class", "id", "title", "lang", "dir"},
+			"a":   {"href", "rel", "target"},
+			"img": {"src", "alt", "width", "height"},
+			"ol":  {"start"},
+			"th":  {"colspan", "rowspan", "align", "scope"},
+			"td":  {"colspan", "rowspan", "align"},
+		},
+		URLSchemes: []string{"http", "https", "mailto", "tel"},
+	}
+}
+
+// ValidatePolicy 는 정책 값을 정규화(소문자, 빈 mode 는 sanitize)하고 허용할 수 없는 항목이 있으면 오류를 반환합니다.
+func ValidatePolicy(policy *models.ContentPolicy) error {
+	if policy.Mode == "" {
+		policy.Mode = models.PolicyModeSanitize
+	}
+	if policy.Mode != models.PolicyModeSanitize && policy.Mode != models.PolicyModeFlag {
+		return errors.New("mode must be sanitize or flag")
+	}
+
+	for i, el := range policy.Elements {
+		el = strings.ToLower(strings.TrimSpace(el))
+		if el == "" {
+			return errors.New("elements must not contain empty names")
+		}
+		if forbiddenElements[el] {
+			return fmt.Errorf("element %q cannot be allowed", el)
+		}
+		policy.Elements[i] = el
+	}
+
+	attributes := make(map[string][]string, len(policy.Attributes))
+	for el, attrs := range policy.Attributes {
+		el = strings.ToLower(strings.TrimSpace(el))
+		for _, attr := range attrs {
+			attr = strings.ToLower(strings.TrimSpace(attr))
+			if attr == "" {
+				return errors.New("attributes must not contain empty names")
+			}
+			if strings.HasPrefix(attr, "on") || attr == "style" || attr == "srcdoc" || attr == "formaction" {
+				return fmt.Errorf("attribute %q cannot be allowed", attr)
+			}
+			attributes[el] = append(attributes[el], attr)
+		}
+	}
+	policy.Attributes = attributes
+
+	for i, scheme := range policy.URLSchemes {
+		scheme = strings.ToLower(strings.TrimSpace(scheme))
+		if forbiddenSchemes[scheme] {
+			return fmt.Errorf("url scheme %q cannot be allowed", scheme)
+		}
+		policy.URLSchemes[i] = scheme
+	}
+	return nil
+}
+
+// Sanitizer 는 ContentPolicy 로 만든 정제기입니다.
+type Sanitizer struct {
+	mode        string
+	fingerprint string
+	elements    map[string]bool
+	attributes  map[string]map[string]bool
+	schemes     map[string]bool
+	policy      *bluemonday.Policy
+}
+
+// Compile 은 ValidatePolicy 를 통과한 정책으로 Sanitizer 를 만듭니다.
+func Compile(policy models.ContentPolicy) *Sanitizer {
+	s := &Sanitizer{
+		mode:       policy.Mode,
+		elements:   make(map[string]bool),
+		attributes: make(map[string]map[string]bool),
+		schemes:    make(map[string]bool),
+		policy:     bluemonday.NewPolicy(),
+	}
+
+	s.policy.AllowElements(policy.Elements...)
+	for _, el := range policy.Elements {
+		s.elements[el] = true
+	}
+	for el, attrs := range policy.Attributes {
+		if el == "*" {
+			s.policy.AllowAttrs(attrs...).Globally()
+		} else {
+			s.policy.AllowAttrs(attrs...).OnElements(el)
+		}
+		if s.attributes[el] == nil {
+			s.attributes[el] = make(map[string]bool)
+		}
+		for _, attr := range attrs {
+			s.attributes[el][attr] = true
+		}
+	}
+	s.policy.AllowURLSchemes(policy.URLSchemes...)
+	s.policy.RequireParseableURLs(true)
+	s.policy.AllowRelativeURLs(true)
+	for _, scheme := range policy.URLSchemes {
+		s.schemes[scheme] = true
+	}
+
+	s.fingerprint = fingerprint(policy)
+	return s
+}
+
+// fingerprint 는 정책 내용의 해시입니다. 렌더링 캐시 키에 써서 정책이 바뀌면 다시 렌더링되게 합니다.
+func fingerprint(policy models.ContentPolicy) string {
+	policy.UpdatedAt = nil
+	for _, attrs := range policy.Attributes {
+		sort.Strings(attrs)
+	}
+	data, _ := json.Marshal(policy)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Flag 는 정책이 flag 모드인지 반환합니다.
+func (s *Sanitizer) Flag() bool {
+	return s.mode == models.PolicyModeFlag
+}
+
+// Sanitize 는 허용 목록에 없는 요소, 속성, URL 을 제거합니다. script, style 은 내용까지 제거됩니다.
+func (s *Sanitizer) Sanitize(source string) string {
+	return s.policy.Sanitize(source)
+}
+
+// Report 는 Sanitize 가 제거할 요소, 속성, URL 을 문서 순서대로 반환합니다.
+func (s *Sanitizer) Report(source string) []models.ContentIssue {
+	issues := []models.ContentIssue{}
+	z := html.NewTokenizer(strings.NewReader(source))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return issues
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if !s.elements[tok.Data] {
+				issues = append(issues, models.ContentIssue{Kind: "element", Element: tok.Data})
+				continue
+			}
+			for _, attr := range tok.Attr {
+				switch {
+				case !s.attributes["*"][attr.Key] && !s.attributes[tok.Data][attr.Key]:
+					issues = append(issues, models.ContentIssue{
+						Kind: "attribute", Element: tok.Data, Attribute: attr.Key, Value: attr.Val,
+					})
+				case urlAttributes[attr.Key] && !s.allowedURL(attr.Val):
+					issues = append(issues, models.ContentIssue{
+						Kind: "url", Element: tok.Data, Attribute: attr.Key, Value: attr.Val,
+					})
+				}
+			}
+		}
+	}
+}
+
+func (s *Sanitizer) allowedURL(value string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || s.schemes[strings.ToLower(u.Scheme)]
+}
+
+// Default 는 DefaultPolicy 로 만든 Sanitizer 입니다.
+var Default = Compile(DefaultPolicy())
+
+// PolicyStore 는 Load 가 사용하는 저장소입니다. store.SiteStore 가 구현합니다.
+type PolicyStore interface {
+	GetContentPolicy(ctx context.Context, siteID int) (*models.ContentPolicy, error)
+}
+
+// Load 는 사이트 본문 정책으로 Sanitizer 를 만듭니다. 정책이 없으면 Default 를 반환합니다.
+func Load(ctx context.Context, policies PolicyStore, siteID int) (*Sanitizer, error) {
+	policy, err := policies.GetContentPolicy(ctx, siteID)
+	if errors.Is(err, store.ErrNotFound) {
+		return Default, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return Compile(*policy), nil
+}
@@ -0,0 +1,79 @@
+package content
+
+import (
+	"pages/internal/models"
+	"reflect"
+	"testing"
+)
+
+// Report 는 Sanitize 가 바꾸는 본문에서만, 바꾸는 항목만 보고해야 합니다.
+func TestReportMatchesSanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []models.ContentIssue
+	}{
+		{"allowed", `<p class="lead">Hello <a href="https://example.com" target="_blank">link</a></p>`, nil},
+		{"relative url", `<a href="/about">about</a><img src="logo.png" alt="logo">`, nil},
+		{"mailto", `<a href="mailto:a@example.com">mail</a>`, nil},
+		{"script", `<p>x</p><script>alert(1)</script>`,
+			[]models.ContentIssue{{Kind: "element", Element: "script"}}},
+		{"unknown element", `<marquee>x</marquee>`,
+			[]models.ContentIssue{{Kind: "element", Element: "marquee"}}},
+		{"event attribute", `<p onclick="alert(1)">x</p>`,
+			[]models.ContentIssue{{Kind: "attribute", Element: "p", Attribute: "onclick", Value: "alert(1)"}}},
+		{"attribute of other element", `<p href="/x">x</p>`,
+			[]models.ContentIssue{{Kind: "attribute", Element: "p", Attribute: "href", Value: "/x"}}},
+		{"javascript url", `<a href="javascript:alert(1)">x</a>`,
+			[]models.ContentIssue{{Kind: "url", Element: "a", Attribute: "href", Value: "javascript:alert(1)"}}},
+		{"data url", `<img src="data:image/png;base64,AAAA">`,
+			[]models.ContentIssue{{Kind: "url", Element: "img", Attribute: "src", Value: "data:image/png;base64,AAAA"}}},
+		{"document order", `<iframe src="/x"></iframe><p style="color:red">x</p>`,
+			[]models.ContentIssue{
+				{Kind: "element", Element: "iframe"},
+				{Kind: "attribute", Element: "p", Attribute: "style", Value: "color:red"},
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Default.Report(tt.source)
+			if len(issues) == 0 {
+				issues = nil
+			}
+			if !reflect.DeepEqual(issues, tt.want) {
+				t.Errorf("Report = %+v, want %+v", issues, tt.want)
+			}
+
+			changed := Default.Sanitize(tt.source) != tt.source
+			if changed != (len(issues) > 0) {
+				t.Errorf("Sanitize changed = %v but Report found %d issues", changed, len(issues))
+			}
+		})
+	}
+}
+
+func TestCompiledPolicy(t *testing.T) {
+	policy := models.ContentPolicy{
+		Mode:       models.PolicyModeFlag,
+		Elements:   []string{"p", "a"},
+		Attributes: map[string][]string{"a": {"href"}},
+		URLSchemes: []string{"https"},
+	}
+	s := Compile(policy)
+	if !s.Flag() {
+		t.Error("Flag() = false for flag mode")
+	}
+
+	source := `<p><a href="http://example.com">x</a><b>y</b></p>`
+	want := []models.ContentIssue{
+		{Kind: "url", Element: "a", Attribute: "href", Value: "http://example.com"},
+		{Kind: "element", Element: "b"},
+	}
+	if issues := s.Report(source); !reflect.DeepEqual(issues, want) {
+		t.Errorf("Report = %+v, want %+v", issues, want)
+	}
+	if s.Sanitize(source) == source {
+		t.Error("Sanitize did not change source with issues")
+	}
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// NewDB 는 DB_DRIVER 환경 변수에 따라 MySQL 또는 SQLite 연결을 생성합니다.
+//   - mysql (기본값): DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME 사용
+//   - sqlite: DB_PATH 의 파일(기본값 pages.db) 또는 :memory: 사용
+//
+// 스키마는 internal/migrate 의 마이그레이션으로 생성합니다.
+func NewDB() (*sql.DB, error) {
+	switch driver := Driver(); driver {
+	case "mysql":
+		return newMySQL()
+	case "sqlite":
+		return newSQLite(getEnv("DB_PATH", "pages.db"))
+	default:
+		return nil, fmt.Errorf("지원하지 않는 DB_DRIVER 입니다: %q", driver)
+	}
+}
+
+// Driver 는 설정된 데이터베이스 드라이버 이름을 반환합니다.
+func Driver() string {
+	return getEnv("DB_DRIVER", "mysql")
+}
+
+// AutoMigrate 는 서버 시작 시 마이그레이션을 적용할지 여부를 반환합니다.
+// DB_AUTO_MIGRATE 가 없으면 SQLite 에서만 자동으로 적용합니다.
+func AutoMigrate() bool {
+	value := getEnv("DB_AUTO_MIGRATE", strconv.FormatBool(Driver() == "sqlite"))
+	enabled, _ := strconv.ParseBool(value)
+	return enabled
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -3,14 +3,14 @@ package database
 import (
 	"database/sql"
 	"fmt"
-	"os"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
-func NewDB() (*sql.DB, error) {
+func newMySQL() (*sql.DB, error) {
 	// 환경 변수에서 데이터베이스 연결 정보 가져오기
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	// multiStatements 는 여러 문장으로 된 마이그레이션 스크립트 실행에 필요합니다.
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local&multiStatements=true",
 		getEnv("DB_USER", "root"),
 		getEnv("DB_PASSWORD", "nhn2025!@"),
 		getEnv("DB_HOST", "localhost"),
@@ -31,10 +31,3 @@ func NewDB() (*sql.DB, error) {
 
 	return db, nil
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"database/sql"
+
+	_ "modernc.org/sqlite"
+)
+
+func newSQLite(path string) (*sql.DB, error) {
+	// 외래 키(ON DELETE CASCADE)는 연결마다 켜야 합니다.
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+
+	// :memory: 데이터베이스는 연결마다 별개이므로 연결을 하나로 고정합니다.
+	// 파일 데이터베이스도 쓰기는 한 번에 하나만 가능하므로 같은 설정을 사용합니다.
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,254 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"pages/internal/models"
+	"pages/internal/pagetree"
+	"pages/internal/render"
+	"pages/internal/sitemap"
+	"pages/internal/store"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManifestFile 은 내보낸 파일 목록을 기록하는 파일 이름입니다. 다음 내보내기에서 바뀐 파일만 다시 쓰고
+// 더 이상 없는 파일을 지우는 데 사용합니다.
+const ManifestFile = "manifest.json"
+
+// Manifest 는 내보내기 결과입니다. Files 의 키는 출력 디렉터리 기준의 슬래시 경로입니다.
+type Manifest struct {
+	Site        string               `json:"site"`
+	BaseURL     string               `json:"base_url"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Files       map[string]FileEntry `json:"files"`
+}
+
+type FileEntry struct {
+	PageID   int    `json:"page_id,omitempty"`
+	Revision int    `json:"revision,omitempty"`
+	SHA256   string `json:"sha256"`
+}
+
+// Result 는 이번 내보내기에서 새로 쓰거나, 그대로 두거나, 지운 파일 목록입니다.
+type Result struct {
+	Written   []string
+	Unchanged []string
+	Removed   []string
+	Skipped   []string // 파일 경로로 쓸 수 없는 slug 를 가진 페이지 경로
+}
+
+type Exporter struct {
+	sites    store.SiteStore
+	pages    store.PageStore
+	renderer *render.Server
+}
+
+func New(sites store.SiteStore, pages store.PageStore) *Exporter {
+	return &Exporter{sites: sites, pages: pages, renderer: render.NewServer(sites, pages)}
+}
+
+type file struct {
+	name string // 출력 디렉터리 기준 슬래시 경로
+	page *models.Page
+	body []byte
+}
+
+// Export 는 사이트의 공개 페이지를 out 디렉터리에 slug 경로별 index.html 로 쓰고
+// sitemap.xml, robots.txt, manifest.json 을 만듭니다. baseURL 이 비어 있으면 https://<사이트 도메인> 을 씁니다.
+func (e *Exporter) Export(ctx context.Context, siteCode, out, baseURL string) (*Result, error) {
+	site, err := e.sites.GetSiteByCode(ctx, siteCode)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("site %q not found", siteCode)
+	} else if err != nil {
+		return nil, err
+	}
+	if baseURL == "" {
+		if baseURL = sitemap.BaseURL(site); baseURL == "" {
+			return nil, fmt.Errorf("site %q has no domain; set --base-url", siteCode)
+		}
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	all, err := e.pages.ListSitePages(ctx, site.SiteID)
+	if err != nil {
+		return nil, err
+	}
+	pages := pagetree.Published(all, time.Now())
+
+	// 렌더링이 메뉴 트리를 다시 만들므로 경로를 먼저 모아 둡니다.
+	result := &Result{}
+	files := []file{{name: "index.html", page: firstRoot(pages)}}
+	pagetree.Walk(pagetree.BuildMenuTree(pages), func(page *models.Page, urlPath string) {
+		if !safePath(urlPath) {
+			result.Skipped = append(result.Skipped, urlPath)
+			return
+		}
+		files = append(files, file{name: strings.TrimPrefix(urlPath, "/") + "/index.html", page: page})
+	})
+
+	for i := range files {
+		urlPath := "/" + strings.TrimSuffix(files[i].name, "index.html")
+		body, _, err := e.renderer.Render(ctx, site, pages, urlPath)
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", urlPath, err)
+		}
+		files[i].body = body
+	}
+
+	// 내보내지 않은 페이지는 sitemap 에서도 뺍니다.
+	skipped := make(map[string]bool, len(result.Skipped))
+	for _, urlPath := range result.Skipped {
+		skipped[baseURL+urlPath] = true
+	}
+	urls := []sitemap.URL{}
+	for _, u := range sitemap.PageURLs(baseURL, pages) {
+		if !skipped[u.Loc] {
+			urls = append(urls, u)
+		}
+	}
+
+	// URL 이 sitemap.MaxURLs 를 넘으면 sitemap-N.xml 로 나누고 sitemap.xml 은 index 로 씁니다.
+	chunks := sitemap.Split(urls)
+	if len(chunks) == 1 {
+		var buf bytes.Buffer
+		if err := sitemap.Write(&buf, chunks[0]); err != nil {
+			return nil, err
+		}
+		files = append(files, file{name: "sitemap.xml", body: buf.Bytes()})
+	} else {
+		locs := make([]string, len(chunks))
+		for i, chunk := range chunks {
+			name := fmt.Sprintf("sitemap-%d.xml", i+1)
+			var buf bytes.Buffer
+			if err := sitemap.Write(&buf, chunk); err != nil {
+				return nil, err
+			}
+			files = append(files, file{name: name, body: buf.Bytes()})
+			locs[i] = baseURL + "/" + name
+		}
+		var buf bytes.Buffer
+		if err := sitemap.WriteIndex(&buf, locs); err != nil {
+			return nil, err
+		}
+		files = append(files, file{name: "sitemap.xml", body: buf.Bytes()})
+	}
+
+	robots := sitemap.Robots(baseURL)
+	if saved, err := e.sites.GetSiteRobots(ctx, site.SiteID); err == nil {
+		robots = saved.Content
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	files = append(files, file{name: "robots.txt", body: []byte(robots)})
+
+	previous := readManifest(out)
+	manifest := Manifest{
+		Site:        site.Code,
+		BaseURL:     baseURL,
+		GeneratedAt: time.Now().UTC(),
+		Files:       make(map[string]FileEntry, len(files)),
+	}
+
+	for _, f := range files {
+		sum := sha256.Sum256(f.body)
+		entry := FileEntry{SHA256: hex.EncodeToString(sum[:])}
+		if f.page != nil {
+			entry.PageID = f.page.PageID
+			entry.Revision = f.page.Revision
+		}
+		manifest.Files[f.name] = entry
+
+		target := filepath.Join(out, filepath.FromSlash(f.name))
+		if old, ok := previous.Files[f.name]; ok && old.SHA256 == entry.SHA256 && exists(target) {
+			result.Unchanged = append(result.Unchanged, f.name)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, f.body, 0o644); err != nil {
+			return nil, err
+		}
+		result.Written = append(result.Written, f.name)
+	}
+
+	for name := range previous.Files {
+		if _, ok := manifest.Files[name]; ok || !safePath("/"+name) {
+			continue
+		}
+		target := filepath.Join(out, filepath.FromSlash(name))
+		if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		removeEmptyDirs(out, filepath.Dir(target))
+		result.Removed = append(result.Removed, name)
+	}
+	sort.Strings(result.Removed)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(out, ManifestFile), append(data, '\n'), 0o644); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readManifest 는 이전 내보내기의 manifest 를 읽습니다. 없거나 읽을 수 없으면 빈 manifest 로 보고 모두 다시 씁니다.
+func readManifest(out string) Manifest {
+	var manifest Manifest
+	data, err := os.ReadFile(filepath.Join(out, ManifestFile))
+	if err == nil {
+		json.Unmarshal(data, &manifest)
+	}
+	return manifest
+}
+
+// safePath 는 slug 경로를 출력 디렉터리 밖으로 벗어나지 않는 파일 경로로 쓸 수 있는지 반환합니다.
+func safePath(urlPath string) bool {
+	if strings.ContainsAny(urlPath, `\:`) || strings.ContainsRune(urlPath, 0) {
+		return false
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(urlPath, "/"), "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return path.Clean(urlPath) == urlPath
+}
+
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+// removeEmptyDirs 는 dir 부터 out 직전까지 비어 있는 디렉터리를 지웁니다.
+func removeEmptyDirs(out, dir string) {
+	out = filepath.Clean(out)
+	for dir = filepath.Clean(dir); dir != out && strings.HasPrefix(dir, out); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+func firstRoot(pages []*models.Page) *models.Page {
+	for _, p := range pages {
+		if p.ParentID == nil {
+			return p
+		}
+	}
+	return nil
+}
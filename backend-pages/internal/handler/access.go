@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/pkg/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// authorize 는 요청 주체가 사이트 siteID 의 그룹 groupID(0 이면 사이트 전체)에서 action 을 할 수 있는지
+// 확인합니다. 권한이 없으면 403 FORBIDDEN 으로 응답하고 false 를 반환합니다.
+func authorize(w http.ResponseWriter, r *http.Request, action rbac.Action, siteID, groupID int) bool {
+	if rbac.FromContext(r.Context()).Can(action, siteID, groupID) {
+		return true
+	}
+	response.Error(w, r, response.NewError(http.StatusForbidden, response.CodeForbidden))
+	return false
+}
+
+// siteFor 는 siteFromPath 로 사이트를 조회하고 사이트 전체에 대한 action 권한을 확인합니다.
+func (h *Handler) siteFor(w http.ResponseWriter, r *http.Request, action rbac.Action) (*models.Site, bool) {
+	site, ok := h.siteFromPath(w, r)
+	if !ok || !authorize(w, r, action, site.SiteID, 0) {
+		return nil, false
+	}
+	return site, true
+}
+
+// inPath 는 URL 의 {siteCode}, {groupId} 가 대상이 속한 사이트 siteID, 그룹 groupID 인지 확인합니다.
+// 옛 사이트 코드는 siteFromPath 처럼 새 코드로 리다이렉트하고, 다른 사이트나 그룹이면 notFound 코드의 404 로 응답합니다.
+func (h *Handler) inPath(w http.ResponseWriter, r *http.Request, siteID, groupID int, notFound response.Code) bool {
+	site, ok := h.siteFromPath(w, r)
+	if !ok {
+		return false
+	}
+	if param := chi.URLParam(r, "groupId"); site.SiteID != siteID || (param != "" && param != strconv.Itoa(groupID)) {
+		response.Error(w, r, response.NotFound(notFound))
+		return false
+	}
+	return true
+}
+
+// groupFor 는 페이지 그룹을 조회하고 그 그룹에 대한 action 권한을 확인합니다. URL 의 사이트에 속한 그룹이어야 합니다.
+func (h *Handler) groupFor(w http.ResponseWriter, r *http.Request, groupID int, action rbac.Action) (*models.PageGroup, bool) {
+	group, err := h.groups.GetPageGroup(r.Context(), groupID)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeGroupNotFound))
+		return nil, false
+	}
+	if !h.inPath(w, r, group.SiteID, group.GroupID, response.CodeGroupNotFound) {
+		return nil, false
+	}
+	if !authorize(w, r, action, group.SiteID, group.GroupID) {
+		return nil, false
+	}
+	return group, true
+}
+
+// pageFor 는 페이지를 조회하고 페이지가 속한 그룹에 대한 action 권한을 확인합니다. 권한은 저장된 페이지의
+// 사이트, 그룹으로 판단하며, URL 의 사이트, 그룹이 이와 다르면 404 입니다.
+func (h *Handler) pageFor(w http.ResponseWriter, r *http.Request, pageID int, action rbac.Action) (*models.Page, bool) {
+	page, err := h.pages.GetPage(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
+		return nil, false
+	}
+	if !h.inPath(w, r, page.SiteID, page.GroupID, response.CodePageNotFound) {
+		return nil, false
+	}
+	if !authorize(w, r, action, page.SiteID, page.GroupID) {
+		return nil, false
+	}
+	return page, true
+}
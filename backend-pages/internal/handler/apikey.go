@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/auth"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/validate"
+	"pages/pkg/response"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListAPIKeys godoc
+// @Summary API 키 목록 조회
+// @Description 발급한 API 키 목록을 조회합니다. 키 원문은 저장하지 않으므로 prefix 로 키를 구분합니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{data=[]models.APIKey}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/api-keys [get]
+func (h *Handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	keys, err := h.keys.ListAPIKeys(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, keys)
+}
+
+// CreateAPIKey godoc
+// @Summary API 키 발급
+// @Description 새 API 키를 발급합니다. 응답의 key 는 이때만 볼 수 있으며, Authorization: Bearer <key> 나 X-API-Key 헤더로 보냅니다. admin 키는 super-admin 입니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param input body models.CreateAPIKeyInput true "키 이름, 관리 권한, 만료 시각"
+// @Success 201 {object} response.Response{data=models.IssuedAPIKey}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/api-keys [post]
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	var input models.CreateAPIKeyInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		response.Error(w, r, response.Invalid(validate.Failed("expires_at", "future", "")))
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	issued, err := auth.IssueAPIKey(r.Context(), h.keys, input.Name, input.Admin, input.ExpiresAt)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	// 키 원문은 이력에도 남기지 않습니다.
+	if !h.record(w, r, 0, models.EntityAPIKey, issued.KeyID, models.AuditCreate, nil, issued.APIKey) {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, issued)
+}
+
+// RevokeAPIKey godoc
+// @Summary API 키 폐기
+// @Description API 키를 폐기합니다. 폐기한 키는 바로 인증에 쓸 수 없으며, 목록에는 revoked_at 과 함께 남습니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param key_id path int true "Key ID"
+// @Success 200 {object} response.Response{data=models.APIKey}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/api-keys/{key_id} [delete]
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	keyID, err := strconv.Atoi(chi.URLParam(r, "keyID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("key_id"))
+		return
+	}
+
+	before, err := h.keys.GetAPIKey(r.Context(), keyID)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeAPIKeyNotFound))
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.keys.RevokeAPIKey(r.Context(), keyID); err != nil {
+		response.Error(w, r, storeError(err, response.CodeAPIKeyNotFound))
+		return
+	}
+
+	key, err := h.keys.GetAPIKey(r.Context(), keyID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if before.RevokedAt == nil {
+		if !h.record(w, r, 0, models.EntityAPIKey, key.KeyID, models.AuditUpdate, before, key) {
+			return
+		}
+	}
+
+	response.JSON(w, http.StatusOK, key)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"pages/internal/auth"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/store"
+	"pages/pkg/response"
+)
+
+// begin 은 요청의 변경과 그 이력을 한 트랜잭션으로 묶습니다. 반환한 요청의 컨텍스트로 부르는 저장소 메서드는
+// 모두 이 트랜잭션 안에서 실행되며 record 가 이력을 쓴 뒤 커밋합니다. 반환한 함수는 커밋하지 않은 변경을
+// 되돌리므로 defer 로 부릅니다. 실패하면 응답을 작성하고 false 를 반환합니다.
+// SQLite 는 연결이 하나뿐이므로 권한 확인, 본문 읽기, 검사를 모두 마친 뒤 첫 변경 직전에 부릅니다.
+func (h *Handler) begin(w http.ResponseWriter, r *http.Request) (*http.Request, func(), bool) {
+	ctx, tx, err := h.audit.Begin(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return r, nil, false
+	}
+	return r.WithContext(ctx), func() { tx.Rollback() }, true
+}
+
+// record 는 요청 주체가 한 변경의 이력을 begin 으로 시작한 트랜잭션에 쓰고 커밋합니다. before, after 는 변경
+// 전후의 대상이며 nil 이면 스냅샷이 없습니다. siteID 가 0 이면 사이트에 속하지 않는 대상입니다. 이력을 쓰거나
+// 커밋하지 못하면 변경도 반영되지 않으므로 오류로 응답하고 false 를 반환합니다.
+func (h *Handler) record(w http.ResponseWriter, r *http.Request, siteID int, entityType string, entityID int, action string, before, after interface{}) bool {
+	event := &models.AuditEvent{
+		SiteID:     optionalID(siteID),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+	}
+	if principal := auth.FromContext(r.Context()); principal != nil {
+		event.Actor, event.ActorName = principal.Subject, principal.Name
+	}
+
+	var err error
+	if event.Before, err = snapshot(before); err == nil {
+		event.After, err = snapshot(after)
+	}
+	if err == nil {
+		err = h.audit.RecordAuditEvent(r.Context(), event)
+	}
+	if err == nil {
+		err = store.Commit(r.Context())
+	}
+	if err != nil {
+		log.Printf("audit: failed to record %s %s %d by %q: %v", action, entityType, entityID, event.Actor, err)
+		response.Error(w, r, err)
+		return false
+	}
+	return true
+}
+
+// saveAction 은 사이트 설정 저장의 이력 동작입니다. 저장 전 설정이 없었으면 생성, 저장 뒤 설정이 없으면 삭제입니다.
+func saveAction(existed, exists bool) string {
+	switch {
+	case !existed:
+		return models.AuditCreate
+	case !exists:
+		return models.AuditDelete
+	}
+	return models.AuditUpdate
+}
+
+// snapshot 은 v 를 JSON 으로 바꿉니다. v 가 nil(nil 포인터 포함)이면 nil 을 반환합니다.
+func snapshot(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAuditEvents godoc
+// @Summary 사이트 변경 이력 조회
+// @Description 사이트의 생성, 수정, 삭제, 이동, 공개 이력을 최신순으로 limit 개씩 조회합니다. before, after 는 변경 전후 대상의 JSON 스냅샷입니다. meta.next_cursor 가 있으면 cursor 로 넘겨 다음 목록을 가져옵니다. site-admin 권한이 필요합니다.
+// @Tags audit
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param entity_type query string false "대상 종류 (site, site_theme, site_robots, content_policy, site_locales, page_group, page, page_translation, role_grant)"
+// @Param entity_id query int false "대상 ID"
+// @Param action query string false "동작 (create, update, delete, move, publish, unpublish)"
+// @Param actor query string false "변경한 인증 주체 (JWT sub, apikey:<key_id> 또는 예약 공개/만료의 scheduler)"
+// @Param since query string false "이 시각(RFC 3339 또는 YYYY-MM-DD) 이후"
+// @Param until query string false "이 시각(RFC 3339 또는 YYYY-MM-DD) 이전"
+// @Param limit query int false "최대 결과 수 (기본 50, 최대 200)"
+// @Param cursor query string false "이전 응답의 meta.next_cursor"
+// @Param sort query string false "정렬 (event_id, 앞에 - 를 붙이면 내림차순). 기본은 -event_id"
+// @Success 200 {object} response.Response{data=[]models.AuditEvent,meta=response.Meta}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/audit [get]
+func (h *Handler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+
+	q, err := listQuery(r, store.AuditList)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	events, next, err := h.audit.QueryAuditEvents(r.Context(), site.SiteID, q)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
+	}
+
+	response.List(w, events, next)
+}
+
+// ListAllAuditEvents godoc
+// @Summary 전체 변경 이력 조회
+// @Description 모든 사이트와 사이트에 속하지 않는 대상(API 키, 사용자)의 변경 이력을 조회합니다. 지운 사이트의 이력은 site_id 로 조회합니다. 필터와 결과 형식은 사이트 변경 이력과 같습니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param site_id query int false "Site ID"
+// @Param entity_type query string false "대상 종류"
+// @Param entity_id query int false "대상 ID"
+// @Param action query string false "동작 (create, update, delete, move, publish, unpublish)"
+// @Param actor query string false "변경한 인증 주체"
+// @Param since query string false "이 시각(RFC 3339 또는 YYYY-MM-DD) 이후"
+// @Param until query string false "이 시각(RFC 3339 또는 YYYY-MM-DD) 이전"
+// @Param limit query int false "최대 결과 수 (기본 50, 최대 200)"
+// @Param cursor query string false "이전 응답의 meta.next_cursor"
+// @Param sort query string false "정렬 (event_id, 앞에 - 를 붙이면 내림차순). 기본은 -event_id"
+// @Success 200 {object} response.Response{data=[]models.AuditEvent,meta=response.Meta}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/audit [get]
+func (h *Handler) ListAllAuditEvents(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	q, err := listQuery(r, store.AdminAuditList)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	events, next, err := h.audit.QueryAuditEvents(r.Context(), 0, q)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.List(w, events, next)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"pages/internal/content"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/store"
+	"pages/pkg/response"
+)
+
+// GetContentPolicy godoc
+// @Summary 사이트 본문 정책 조회
+// @Description 페이지 본문에 허용할 HTML 요소, 속성, URL 스킴 목록을 조회합니다. 저장된 정책이 없으면 기본 정책을 반환합니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Success 200 {object} response.Response{data=models.ContentPolicy}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/content-policy [get]
+func (h *Handler) GetContentPolicy(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+
+	policy, err := h.sites.GetContentPolicy(r.Context(), site.SiteID)
+	if errors.Is(err, store.ErrNotFound) {
+		defaultPolicy := content.DefaultPolicy()
+		policy = &defaultPolicy
+	} else if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, policy)
+}
+
+// SaveContentPolicy godoc
+// @Summary 사이트 본문 정책 저장
+// @Description 페이지 본문 허용 목록을 저장합니다. mode 가 sanitize 이면 html 형식 본문은 저장할 때 정제되고, flag 이면 그대로 저장한 뒤 조회 시 content_issues 로 표시합니다. script, style 등의 요소와 on* 이벤트 속성, javascript 스킴은 허용할 수 없습니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param policy body models.ContentPolicy true "본문 정책"
+// @Success 200 {object} response.Response{data=models.ContentPolicy}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/content-policy [put]
+func (h *Handler) SaveContentPolicy(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+
+	var policy models.ContentPolicy
+	if err := decode(w, r, &policy); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if err := content.ValidatePolicy(&policy); err != nil {
+		response.Error(w, r, response.Validation(err.Error()))
+		return
+	}
+
+	// 이력에 남길 이전 정책입니다. 없으면(ErrNotFound) nil 입니다.
+	before, _ := h.sites.GetContentPolicy(r.Context(), site.SiteID)
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.sites.SaveContentPolicy(r.Context(), site.SiteID, policy); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	saved, err := h.sites.GetContentPolicy(r.Context(), site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntityContentPolicy, site.SiteID, saveAction(before != nil, true), before, saved) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, saved)
+}
+
+// DryRunContentPolicy godoc
+// @Summary 본문 정제 미리보기
+// @Description 본문을 사이트 정책(또는 요청에 포함한 정책)으로 정제한 결과와 제거될 요소, 속성, URL 목록을 반환합니다. 아무것도 저장하지 않습니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param input body models.SanitizeDryRunInput true "본문"
+// @Success 200 {object} response.Response{data=models.SanitizeDryRunResult}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/content-policy/dry-run [post]
+func (h *Handler) DryRunContentPolicy(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+
+	var input models.SanitizeDryRunInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if input.ContentFormat == "" {
+		input.ContentFormat = models.ContentFormatHTML
+	}
+
+	var sanitizer *content.Sanitizer
+	if input.Policy != nil {
+		if err := content.ValidatePolicy(input.Policy); err != nil {
+			response.Error(w, r, response.Validation(err.Error()))
+			return
+		}
+		sanitizer = content.Compile(*input.Policy)
+	} else {
+		var err error
+		if sanitizer, err = content.Load(r.Context(), h.sites, site.SiteID); err != nil {
+			response.Error(w, r, err)
+			return
+		}
+	}
+
+	rendered := content.Render(input.ContentFormat, input.Content)
+	result := models.SanitizeDryRunResult{
+		ContentHTML: sanitizer.Sanitize(rendered),
+		Removed:     sanitizer.Report(rendered),
+	}
+	result.Changed = len(result.Removed) > 0
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// cleanContent 는 사이트 정책이 sanitize 모드이고 본문이 html 형식이면 저장 전에 정제합니다.
+// markdown 본문은 원문을 보존하고 content_html 을 만들 때 정제합니다.
+func (h *Handler) cleanContent(ctx context.Context, siteID int, page *models.Page) error {
+	if page.ContentFormat != models.ContentFormatHTML {
+		return nil
+	}
+	sanitizer, err := content.Load(ctx, h.sites, siteID)
+	if err != nil {
+		return err
+	}
+	if !sanitizer.Flag() {
+		page.Content = sanitizer.Sanitize(page.Content)
+	}
+	return nil
+}
+
+// renderContent 는 응답에 담을 content_html 과, 정책이 flag 모드이면 content_issues 를 채웁니다.
+func (h *Handler) renderContent(ctx context.Context, page *models.Page) error {
+	sanitizer, err := content.Load(ctx, h.sites, page.SiteID)
+	if err != nil {
+		return err
+	}
+	page.ContentHTML = h.content.Page(page, sanitizer)
+	if sanitizer.Flag() {
+		page.ContentIssues = sanitizer.Report(content.Render(page.ContentFormat, page.Content))
+	}
+	return nil
+}
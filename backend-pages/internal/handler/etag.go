@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"pages/pkg/response"
+	"strconv"
+	"strings"
+)
+
+// 사이트, 페이지와 그룹의 ETag 는 행 버전(version 컬럼)으로 만든 "<종류>-<id>-<버전>" 형식의 강한 ETag 입니다.
+// 수정, 삭제 요청은 이 값을 If-Match 로 보내 그 사이에 다른 요청이 바꾸지 않았을 때만 반영합니다.
+// 목록과 메뉴는 포함된 행의 id, 버전으로 만든 약한 ETag 를 쓰며 If-None-Match 에만 씁니다.
+const (
+	etagSite  = "site"
+	etagPage  = "page"
+	etagGroup = "group"
+)
+
+func versionETag(kind string, id, version int) string {
+	return fmt.Sprintf(`"%s-%d-%d"`, kind, id, version)
+}
+
+// listETag 는 parts 를 이어 만든 해시로 약한 ETag 를 만듭니다.
+func listETag(parts ...interface{}) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(hash, "%v\x00", part)
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// notModified 는 ETag 헤더를 설정하고, If-None-Match 가 etag 와 맞으면(약한 비교) 304 를 보낸 뒤 true 를 반환합니다.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// localeETag 는 강한 ETag etag 에 응답 언어를 붙입니다. 같은 URL 도 Accept-Language 에 따라 본문이 달라지므로
+// 언어마다 다른 ETag 를 씁니다("page-1-3.en"). If-Match 는 버전만 비교하므로 언어가 붙은 ETag 도 받습니다.
+func localeETag(etag, locale string) string {
+	return strings.TrimSuffix(etag, `"`) + "." + locale + `"`
+}
+
+// ifMatch 는 If-Match 헤더를 kind 리소스 id 의 현재 버전 current 와 비교합니다. 헤더가 없거나 * 이면 0 을 반환해
+// 버전을 확인하지 않습니다. 나열한 강한 ETag 중 하나라도 현재 버전이면 current 를 반환하며, 저장소는 그 사이
+// 다른 요청이 버전을 바꾸지 않았을 때만 반영합니다. 맞는 ETag 가 없으면 412 오류를 반환합니다.
+func ifMatch(r *http.Request, kind string, id, current int) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return 0, nil
+	}
+	prefix := fmt.Sprintf(`"%s-%d-`, kind, id)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return 0, nil
+		}
+		// 약한 ETag(W/"...")는 prefix 와 맞지 않아 건너뜁니다. If-Match 는 강한 비교만 합니다.
+		if !strings.HasPrefix(tag, prefix) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		value, _, _ := strings.Cut(tag[len(prefix):len(tag)-1], ".")
+		if version, err := strconv.Atoi(value); err == nil && version == current {
+			return current, nil
+		}
+	}
+	return 0, response.NewError(http.StatusPreconditionFailed, response.CodePreconditionFailed)
+}
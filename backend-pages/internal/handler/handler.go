@@ -1,87 +1,287 @@
 package handler
 
 import (
-	"database/sql"
+	"bytes"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"pages/internal/content"
+	"pages/internal/listquery"
+	"pages/internal/mergepatch"
 	"pages/internal/models"
+	"pages/internal/pagetree"
+	"pages/internal/rbac"
+	"pages/internal/search"
+	"pages/internal/store"
+	"pages/internal/validate"
+	"pages/pkg/response"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type Handler struct {
-	db *sql.DB
+	sites  store.SiteStore
+	groups store.PageGroupStore
+	pages  store.PageStore
+	keys   store.APIKeyStore
+	access store.AccessStore
+	audit  store.AuditStore
+
+	search *search.Service
+
+	// content 는 리비전별 content_html 렌더링 결과를 캐시합니다.
+	content *content.Renderer
 }
 
-func NewHandler(db *sql.DB) *Handler {
-	return &Handler{db: db}
+// contentCacheSize 는 캐시할 리비전 렌더링 결과의 최대 개수입니다.
+const contentCacheSize = 1024
+
+func NewHandler(sites store.SiteStore, groups store.PageGroupStore, pages store.PageStore, keys store.APIKeyStore, access store.AccessStore, audit store.AuditStore, search *search.Service) *Handler {
+	return &Handler{
+		sites:   sites,
+		groups:  groups,
+		pages:   pages,
+		keys:    keys,
+		access:  access,
+		audit:   audit,
+		search:  search,
+		content: content.NewRenderer(contentCacheSize),
+	}
+}
+
+// maxBodyBytes 는 요청 본문의 최대 크기입니다. 사이트 테마 템플릿(최대 1MB)이 들어갈 수 있어야 합니다.
+const maxBodyBytes = 2 << 20
+
+// decode 는 요청 본문을 v 로 읽고 validate 태그로 검사합니다. 모르는 필드가 있으면 INVALID_BODY,
+// 검사에 실패하면 필드별 details 가 담긴 VALIDATION_FAILED 오류를 반환합니다.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return decodeJSON(w, r, v, true)
+}
+
+// decodeJSON 은 decode 와 같지만 strict 가 false 이면 모르는 필드를 무시합니다.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}, strict bool) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return response.InvalidBody(err)
+	}
+	if dec.More() {
+		return response.InvalidBody(errors.New("unexpected data after JSON body"))
+	}
+	if err := validate.Struct(v); err != nil {
+		return response.Invalid(err)
+	}
+	return nil
+}
+
+// decodePatch 는 요청 본문의 JSON merge patch(RFC 7396)를 v 에 적용하고 validate 태그로 검사합니다.
+// v 는 현재 값으로 채운 구조체 포인터이며 적용 결과로 바뀝니다. patch 가 바꾸는 필드 이름을 반환합니다.
+// Content-Type 은 application/merge-patch+json 또는 application/json 이어야 합니다.
+func decodePatch(w http.ResponseWriter, r *http.Request, v interface{}) (map[string]bool, error) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType != mergepatch.ContentType && mediaType != "application/json" {
+			return nil, response.NewError(http.StatusUnsupportedMediaType, response.CodeUnsupportedMedia, contentType)
+		}
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		return nil, response.InvalidBody(err)
+	}
+	fields, err := mergepatch.Fields(patch)
+	if err != nil {
+		return nil, response.InvalidBody(err)
+	}
+	current, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := mergepatch.Apply(current, patch)
+	if err != nil {
+		return nil, response.InvalidBody(err)
+	}
+
+	// null 로 지운 필드가 이전 값으로 남지 않도록 비운 뒤 읽습니다.
+	target := reflect.ValueOf(v).Elem()
+	target.Set(reflect.Zero(target.Type()))
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return nil, response.InvalidBody(err)
+	}
+	if err := validate.Struct(v); err != nil {
+		return nil, response.Invalid(err)
+	}
+	return fields, nil
+}
+
+// siteFromPath 는 URL 의 siteCode 로 사이트를 조회합니다. siteCode 가 코드를 바꾸기 전의 옛 코드이면
+// 현재 코드의 같은 경로로 리다이렉트(308)합니다. 실패하면 응답을 작성하고 false 를 반환합니다.
+func (h *Handler) siteFromPath(w http.ResponseWriter, r *http.Request) (*models.Site, bool) {
+	code := chi.URLParam(r, "siteCode")
+	site, err := h.sites.GetSiteByCode(r.Context(), code)
+	if errors.Is(err, store.ErrNotFound) {
+		if renamed, aliasErr := h.sites.GetSiteByAlias(r.Context(), code); aliasErr == nil {
+			redirectSite(w, r, code, renamed.Code)
+			return nil, false
+		}
+	}
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return nil, false
+	}
+	return site, true
+}
+
+// redirectSite 는 요청 경로의 /api/sites/{from} 을 /api/sites/{to} 로 바꿔 리다이렉트합니다.
+// 308 은 메서드와 본문을 유지하므로 옛 코드로 보낸 수정 요청도 그대로 다시 보낼 수 있습니다.
+func redirectSite(w http.ResponseWriter, r *http.Request, from, to string) {
+	prefix := "/api/sites/" + from
+	path := r.URL.Path
+	if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		response.Error(w, r, response.NotFound(response.CodeSiteNotFound))
+		return
+	}
+	location := "/api/sites/" + to + path[len(prefix):]
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, location, http.StatusPermanentRedirect)
+}
+
+// storeError 는 저장소 오류를 응답 오류로 바꿉니다. ErrNotFound 는 notFound 코드의 404 가 되며,
+// 알 수 없는 오류는 그대로 반환해 response.Error 가 내부 오류로 처리하게 합니다.
+func storeError(err error, notFound response.Code) error {
+	var apiErr *response.APIError
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return response.NotFound(notFound)
+	case errors.Is(err, store.ErrSlugConflict):
+		return response.NewError(http.StatusConflict, response.CodeSlugConflict)
+	case errors.Is(err, store.ErrConflict):
+		return response.NewError(http.StatusConflict, response.CodeConflict)
+	case errors.Is(err, store.ErrVersionMismatch):
+		return response.NewError(http.StatusPreconditionFailed, response.CodePreconditionFailed)
+	case errors.Is(err, listquery.ErrCursor):
+		return response.InvalidParameter("cursor")
+	case errors.Is(err, store.ErrInvalidParent):
+		apiErr = response.NewError(http.StatusBadRequest, response.CodeInvalidParent)
+	case errors.Is(err, store.ErrCycle):
+		apiErr = response.NewError(http.StatusBadRequest, response.CodePageCycle)
+	case errors.Is(err, store.ErrInvalidPosition):
+		apiErr = response.NewError(http.StatusBadRequest, response.CodeInvalidPosition)
+	case errors.Is(err, store.ErrInvalidTree):
+		apiErr = response.NewError(http.StatusBadRequest, response.CodeInvalidTree)
+	default:
+		return err
+	}
+	// 검사 오류에 덧붙은 이유(예: 빠진 페이지 수)는 요청 언어로 번역해 details 로 보냅니다.
+	var reasonErr *store.ReasonError
+	if errors.As(err, &reasonErr) {
+		apiErr.Details = response.Reason{Reason: reasonErr.Reason, Args: reasonErr.Args}
+	}
+	return apiErr
+}
+
+// listQuery 는 목록 요청의 limit, cursor, sort 와 spec 의 필터 파라미터를 해석합니다.
+func listQuery(r *http.Request, spec listquery.Spec) (*listquery.Query, error) {
+	q, err := listquery.Parse(r.URL.Query(), spec)
+	var paramErr *listquery.ParamError
+	if errors.As(err, &paramErr) {
+		return nil, response.InvalidParameter(paramErr.Param)
+	}
+	return q, err
 }
 
 // GetSites godoc
-// @Summary 모든 사이트 목록 조회
-// @Description 등록된 모든 사이트의 목록을 조회합니다.
+// @Summary 사이트 목록 조회
+// @Description 역할이 있는 사이트 목록을 limit 개씩 조회합니다(super-admin 은 모든 사이트). meta.next_cursor 가 있으면 cursor 로 넘겨 다음 목록을 가져옵니다.
 // @Tags sites
 // @Accept json
 // @Produce json
-// @Success 200 {array} models.Site
+// @Param limit query int false "최대 결과 수 (기본 50, 최대 200)"
+// @Param cursor query string false "이전 응답의 meta.next_cursor"
+// @Param sort query string false "정렬 (code, name, created_at, updated_at, 앞에 - 를 붙이면 내림차순, 쉼표로 여러 개). 기본은 site_id 순"
+// @Param name query string false "이름에 포함된 문자열"
+// @Param updated_since query string false "이 시각(RFC 3339 또는 YYYY-MM-DD) 이후 수정(또는 생성)된 사이트"
+// @Success 200 {object} response.Response{data=[]models.Site,meta=response.Meta}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites [get]
 func (h *Handler) GetSites(w http.ResponseWriter, r *http.Request) {
-	rows, err := h.db.Query("SELECT site_id, code, name, domain, created_at, updated_at FROM sites")
+	q, err := listQuery(r, store.SiteList)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
-	defer rows.Close()
+	if perms := rbac.FromContext(r.Context()); !perms.SuperAdmin() {
+		q.Restrict("site_id", perms.SiteIDs())
+	}
 
-	var sites []models.Site
-	for rows.Next() {
-		var site models.Site
-		if err := rows.Scan(&site.SiteID, &site.Code, &site.Name, &site.Domain, &site.CreatedAt, &site.UpdatedAt); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		sites = append(sites, site)
+	sites, next, err := h.sites.QuerySites(r.Context(), q)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
 	}
 
-	json.NewEncoder(w).Encode(sites)
+	response.List(w, sites, next)
 }
 
 // CreateSite godoc
 // @Summary 사이트 생성
-// @Description 새로운 사이트를 생성합니다.
+// @Description 새로운 사이트를 생성합니다. super-admin 권한이 필요합니다.
 // @Tags sites
 // @Accept json
 // @Produce json
 // @Param site body models.CreateSiteInput true "Site Info"
-// @Success 201 {object} map[string]int
-// @Failure 400 {object} map[string]string
+// @Success 201 {object} response.Response{data=map[string]int}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites [post]
 func (h *Handler) CreateSite(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
 	var input models.CreateSiteInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
-	result, err := h.db.Exec(
-		"INSERT INTO sites (code, name, domain) VALUES (?, ?, ?)",
-		input.Code, input.Name, input.Domain,
-	)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
 		return
 	}
+	defer rollback()
 
-	id, err := result.LastInsertId()
+	id, err := h.sites.CreateSite(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
+	}
+	site, err := h.sites.GetSiteByCode(r.Context(), input.Code)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntitySite, site.SiteID, models.AuditCreate, nil, site) {
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	response.JSON(w, http.StatusCreated, map[string]interface{}{
 		"created": true,
 		"site_id": id,
 	})
@@ -89,414 +289,579 @@ func (h *Handler) CreateSite(w http.ResponseWriter, r *http.Request) {
 
 // GetSiteMenu godoc
 // @Summary 전체 메뉴 조회
-// @Description 사이트의 전체 메뉴를 조회합니다.
+// @Description 사이트의 전체 메뉴를 조회합니다. 기본적으로 공개된 페이지의 공개 스냅샷만 포함하며, preview=true 이면 볼 권한(viewer 이상)이 있는 그룹은 초안을 포함한 모든 페이지를 조회합니다. 제목과 slug 는 요청 언어(locale 또는 Accept-Language)의 번역이며, 번역이 없으면 사이트 fallback 순서에 따라 다른 언어나 원문을 씁니다. ETag 는 메뉴에 포함된 사이트, 그룹, 페이지의 버전으로 만들며, If-None-Match 가 같으면 304 를 반환합니다.
 // @Tags menu
 // @Accept json
 // @Produce json
 // @Param site_code path string true "Site Code"
-// @Success 200 {array} models.Page
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param preview query bool false "초안 미리보기"
+// @Param locale query string false "언어 (생략하면 Accept-Language)"
+// @Param Accept-Language header string false "요청 언어"
+// @Param If-None-Match header string false "캐시한 응답의 ETag"
+// @Success 200 {object} response.Response{data=[]models.Page}
+// @Success 304
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites/{site_code}/menu [get]
 func (h *Handler) GetSiteMenu(w http.ResponseWriter, r *http.Request) {
-	siteCode := chi.URLParam(r, "siteCode")
-	fmt.Println("siteCode : ", siteCode)
-
-	// 사이트 정보 조회
-	var site models.Site
-	err := h.db.QueryRow(
-		"SELECT site_id, code, name, domain, created_at, updated_at FROM sites WHERE code = ?",
-		siteCode,
-	).Scan(&site.SiteID, &site.Code, &site.Name, &site.Domain, &site.CreatedAt, &site.UpdatedAt)
-	if err == sql.ErrNoRows {
-		http.Error(w, "사이트를 찾을 수 없습니다", http.StatusNotFound)
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
 		return
-	} else if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	perms := rbac.FromContext(r.Context())
+	preview, _ := strconv.ParseBool(r.URL.Query().Get("preview"))
+	localizer, ok := h.localizer(w, r, site.SiteID, 0)
+	if !ok {
 		return
 	}
 
 	// 그룹 조회
-	groupRows, err := h.db.Query(
-		"SELECT group_id, site_id, name, description, created_at, updated_at FROM page_groups WHERE site_id = ? ORDER BY name",
-		site.SiteID,
-	)
+	pageGroups, err := h.groups.ListPageGroups(r.Context(), site.SiteID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
-	defer groupRows.Close()
 
-	var pageGroups []models.PageGroup
-	for groupRows.Next() {
-		var group models.PageGroup
-		if err := groupRows.Scan(&group.GroupID, &group.SiteID, &group.Name, &group.Description, &group.CreatedAt, &group.UpdatedAt); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		// 각 그룹의 메뉴 조회
-		pageRows, err := h.db.Query(
-			`SELECT page_id, site_id, group_id, title, slug, parent_id, depth, menu_order, 
-			content, is_published, created_at, updated_at 
-			FROM pages 
-			WHERE site_id = ? AND group_id = ? 
-			ORDER BY depth, menu_order`,
-			site.SiteID, group.GroupID,
-		)
+	// 각 그룹의 메뉴 조회. 번역 저장과 언어 설정 변경도 페이지, 사이트 버전을 올리므로 포함된 행의 버전으로
+	// ETag 를 만듭니다. 예약 공개로 목록이 바뀌면 포함된 페이지가 달라져 ETag 도 바뀝니다.
+	parts := []interface{}{site.SiteID, site.Version, localizer.Locale, preview}
+	for i := range pageGroups {
+		pages, err := h.pages.ListPages(r.Context(), site.SiteID, pageGroups[i].GroupID)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			response.Error(w, r, err)
 			return
 		}
-		defer pageRows.Close()
-
-		pages := []*models.Page{} // ← 빈 슬라이스로 초기화
-
-		for pageRows.Next() {
-			var page models.Page
-			if err := pageRows.Scan(
-				&page.PageID, &page.SiteID, &page.GroupID, &page.Title, &page.Slug,
-				&page.ParentID, &page.Depth, &page.MenuOrder, &page.Content,
-				&page.IsPublished, &page.CreatedAt, &page.UpdatedAt,
-			); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-
-			fmt.Println("page : ", page)
-
-			pages = append(pages, &page)
+		// 초안을 볼 권한이 없는 그룹은 미리보기에서도 공개 메뉴를 보여 줍니다.
+		draft := preview && perms.Can(rbac.ActionView, site.SiteID, pageGroups[i].GroupID)
+		if !draft {
+			pages = pagetree.Published(pages, time.Now())
 		}
-
-		fmt.Println("pages : ", len(pages))
-
-		group.Menu = BuildMenuTree(pages)
-		pageGroups = append(pageGroups, group)
+		parts = append(parts, "group", pageGroups[i].GroupID, pageGroups[i].Version, draft)
+		for _, page := range pages {
+			parts = append(parts, page.PageID, page.Version)
+		}
+		pageGroups[i].Menu = pagetree.BuildMenuTree(localizer.Pages(pages, !draft))
+	}
+	if notModified(w, r, listETag(parts...)) {
+		return
 	}
 
-	response := struct {
+	menu := struct {
 		models.Site
+		Locale     string             `json:"locale"`
 		PageGroups []models.PageGroup `json:"page_groups"`
 	}{
-		Site:       site,
+		Site:       *site,
+		Locale:     localizer.Locale,
 		PageGroups: pageGroups,
 	}
 
-	json.NewEncoder(w).Encode(response)
+	response.JSON(w, http.StatusOK, menu)
 }
 
 // CreatePage godoc
 // @Summary 페이지 생성
-// @Description 페이지 생성
+// @Description 페이지를 초안(비공개) 상태로 생성합니다. 공개하려면 publish 를 호출합니다. html 형식 본문은 사이트 본문 정책이 sanitize 모드이면 저장 전에 정제됩니다. publish_at, unpublish_at 을 지정하려면 publish 권한이 필요합니다.
 // @Tags pages
 // @Accept json
 // @Produce json
 // @Param site_code path string true "Site Code"
 // @Param group_id path int true "Group ID"
 // @Param page body models.CreatePageInput true "Page Information"
-// @Success 201 {object} models.Page
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 201 {object} response.Response{data=models.Page}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites/{site_code}/groups/{group_id}/pages [post]
 func (h *Handler) CreatePage(w http.ResponseWriter, r *http.Request) {
 	var input models.CreatePageInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
-	siteCode := chi.URLParam(r, "siteCode")
 	groupId, err := strconv.Atoi(chi.URLParam(r, "groupId"))
 	if err != nil {
-		http.Error(w, "Invalid group_id", http.StatusBadRequest)
+		response.Error(w, r, response.InvalidParameter("group_id"))
 		return
 	}
+	if err := validSchedule(input.PublishAt, input.UnpublishAt); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if input.ContentFormat == "" {
+		input.ContentFormat = models.ContentFormatHTML
+	}
 
 	// 사이트 ID 조회
-	var siteID int
-	err2 := h.db.QueryRow("SELECT site_id FROM sites WHERE code = ?", siteCode).Scan(&siteID)
-	if err2 == sql.ErrNoRows {
-		http.Error(w, "사이트를 찾을 수 없습니다", http.StatusNotFound)
-		return
-	} else if err2 != nil {
-		http.Error(w, err2.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// 부모 페이지가 있는 경우 depth 계산
-	depth := 0
-	// if input.ParentID != nil {
-	// 	err := h.db.QueryRow("SELECT depth FROM pages WHERE id = ?", *input.ParentID).Scan(&depth)
-	// 	if err != nil {
-	// 		http.Error(w, "부모 페이지를 찾을 수 없습니다", http.StatusBadRequest)
-	// 		return
-	// 	}
-	// 	depth++
-	// }
+	site, ok := h.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	group, ok := h.groupFor(w, r, groupId, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+	// 예약 공개/만료는 스케줄러가 공개 상태를 바꾸므로 공개 권한이 필요합니다.
+	if scheduleChanged(nil, input.PublishAt, input.UnpublishAt) && !authorize(w, r, rbac.ActionPublish, site.SiteID, group.GroupID) {
+		return
+	}
+
 	var parentID *int
 	if input.ParentID != nil && *input.ParentID != 0 {
 		parentID = input.ParentID
 	}
 
-	result, err := h.db.Exec(
-		`INSERT INTO pages (site_id, group_id, title, slug, parent_id, depth, menu_order, content, is_published) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		siteID, groupId, input.Title, input.Slug, parentID, depth, 0, input.Content, true,
-	)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	page := &models.Page{
+		SiteID:        site.SiteID,
+		GroupID:       groupId,
+		Title:         input.Title,
+		Slug:          input.Slug,
+		ParentID:      parentID,
+		Content:       input.Content,
+		ContentFormat: input.ContentFormat,
+		IsPublished:   false,
+		PublishAt:     input.PublishAt,
+		UnpublishAt:   input.UnpublishAt,
+	}
+	if err := h.cleanContent(r.Context(), site.SiteID, page); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
 		return
 	}
+	defer rollback()
 
-	fmt.Println("마지막 생성!!!", id)
+	id, err := h.pages.CreatePage(r.Context(), page)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
+		return
+	}
 
 	// 생성된 페이지 조회
-	var page models.Page
-
-	err = h.db.QueryRow(
-		"SELECT page_id, site_id, group_id, title, slug, parent_id, depth, menu_order, content, is_published, created_at, updated_at FROM pages WHERE id = ?", id,
-	).Scan(
-		&page.PageID, &page.SiteID, &page.GroupID, &page.Title, &page.Slug,
-		&page.ParentID, &page.Depth, &page.MenuOrder, &page.Content,
-		&page.IsPublished, &page.CreatedAt, &page.UpdatedAt,
-	)
+	page, err = h.pages.GetPage(r.Context(), int(id))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, page.SiteID, models.EntityPage, page.PageID, models.AuditCreate, nil, page) {
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(page)
+	response.JSON(w, http.StatusCreated, page)
 }
 
-func BuildMenuTree(pages []*models.Page) []*models.Page {
-	pageMap := make(map[int]*models.Page)
-
-	// 모든 페이지를 맵에 저장
-	for _, page := range pages {
-		page.Menu = []*models.Page{} // 초기화
-		pageMap[page.PageID] = page
-	}
-
-	// 트리 구성
-	var roots []*models.Page
-	for _, page := range pages {
-		if page.ParentID != nil {
-			if parent, exists := pageMap[*page.ParentID]; exists {
-				parent.Menu = append(parent.Menu, page)
-			}
-		} else {
-			roots = append(roots, page)
-		}
+// validSchedule 은 공개/만료 시각이 모두 있을 때 만료가 공개보다 뒤인지 확인합니다.
+func validSchedule(publishAt, unpublishAt *time.Time) error {
+	if publishAt == nil || unpublishAt == nil || unpublishAt.After(*publishAt) {
+		return nil
 	}
+	return response.Invalid(validate.Failed("unpublish_at", "after", "publish_at"))
+}
 
-	// 디버깅 출력 (선택적)
-	for _, page := range pages {
-		fmt.Printf("Page ID: %d, Parent ID: %v, Children: %d\n", page.PageID, page.ParentID, len(page.Menu))
+// scheduleChanged 는 공개/만료 시각이 current(nil 이면 새 페이지)의 시각과 다른지 반환합니다.
+func scheduleChanged(current *models.Page, publishAt, unpublishAt *time.Time) bool {
+	if current == nil {
+		return publishAt != nil || unpublishAt != nil
 	}
-	printChildren(roots, 0)
-
-	return roots
+	return !sameTime(current.PublishAt, publishAt) || !sameTime(current.UnpublishAt, unpublishAt)
 }
 
-func printChildren(pages []*models.Page, level int) {
-	for _, page := range pages {
-		indent := strings.Repeat("  ", level)
-		fmt.Printf("%sPage ID: %d, Children: %d\n", indent, page.PageID, len(page.Menu))
-		if len(page.Menu) > 0 {
-			printChildren(page.Menu, level+1)
-		}
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
 	}
+	return a.Equal(*b)
 }
 
 // ListPages godoc
-// @Summary List all pages
-// @Description Retrieve a list of all pages for a site and group
+// @Summary List pages
+// @Description Retrieve the pages of a group, limit at a time, in menu order (depth, menu_order) unless sort is given. Pass meta.next_cursor back as cursor to fetch the next page.
 // @Tags pages
 // @Accept json
 // @Produce json
 // @Param site_code path string true "Site Code"
 // @Param group_id path int true "Group ID"
-// @Success 200 {array} models.Page
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param limit query int false "Maximum number of pages (default 50, max 200)"
+// @Param cursor query string false "meta.next_cursor of the previous response"
+// @Param sort query string false "Comma separated sort fields (title, slug, depth, menu_order, created_at, updated_at), prefix with - for descending"
+// @Param is_published query bool false "Published state"
+// @Param parent_id query string false "Parent page ID, or null for top level pages"
+// @Param depth query int false "Depth"
+// @Param updated_since query string false "Updated (or created) at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param title query string false "Substring of the title"
+// @Success 200 {object} response.Response{data=[]models.Page,meta=response.Meta}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites/{site_code}/groups/{group_id}/pages [get]
 func (h *Handler) ListPages(w http.ResponseWriter, r *http.Request) {
-	siteCode := chi.URLParam(r, "siteCode")
 	groupId, err := strconv.Atoi(chi.URLParam(r, "groupId"))
 	if err != nil {
-		http.Error(w, "Invalid group ID", http.StatusBadRequest)
+		response.Error(w, r, response.InvalidParameter("group_id"))
 		return
 	}
 
-	rows, err := h.db.Query(`
-		SELECT page_id, site_id, group_id, title, slug, parent_id, depth, 
-		menu_order, content, is_published, created_at, updated_at
-		FROM pages 
-		WHERE site_id = (SELECT site_id FROM sites WHERE code = ?)
-		AND group_id = ?
-		ORDER BY depth, menu_order
-	`, siteCode, groupId)
+	site, ok := h.siteFromPath(w, r)
+	if !ok || !authorize(w, r, rbac.ActionView, site.SiteID, groupId) {
+		return
+	}
+
+	q, err := listQuery(r, store.PageList)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
-	defer rows.Close()
 
-	var pages []models.Page
-	for rows.Next() {
-		var page models.Page
-		if err := rows.Scan(
-			&page.PageID, &page.SiteID, &page.GroupID, &page.Title, &page.Slug,
-			&page.ParentID, &page.Depth, &page.MenuOrder, &page.Content,
-			&page.IsPublished, &page.CreatedAt, &page.UpdatedAt,
-		); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		pages = append(pages, page)
+	pages, next, err := h.pages.QueryPages(r.Context(), site.SiteID, groupId, q)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeGroupNotFound))
+		return
 	}
 
-	json.NewEncoder(w).Encode(pages)
+	response.List(w, pages, next)
 }
 
 // GetPage godoc
 // @Summary Get page by ID
-// @Description Retrieve a specific page by its ID
+// @Description Retrieve a specific page by its ID. The ETag header carries the page version and the response locale (e.g. "page-1-3.en") and can be sent as If-Match on updates; If-Match may list several entity-tags or *. With published=true, returns the published snapshot only while the page is live. content_html holds the content rendered according to content_format and sanitized. Title, slug and content are taken from the translation for the requested locale (locale or Accept-Language), following the site fallback chain; locale reports which one was used.
 // @Tags pages
 // @Accept json
 // @Produce json
 // @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
 // @Param page_id path int true "Page ID"
-// @Success 200 {object} models.Page
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/sites/{site_code}/pages/{page_id} [get]
+// @Param published query bool false "Return the live published snapshot"
+// @Param locale query string false "Locale (defaults to Accept-Language)"
+// @Param Accept-Language header string false "Preferred locales"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Success 200 {object} response.Response{data=models.Page}
+// @Success 304
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id} [get]
 func (h *Handler) GetPage(w http.ResponseWriter, r *http.Request) {
 	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
 	if err != nil {
-		http.Error(w, "Invalid page ID", http.StatusBadRequest)
+		response.Error(w, r, response.InvalidParameter("page_id"))
 		return
 	}
 
-	var page models.Page
-	err = h.db.QueryRow(`
-		SELECT page_id, site_id, group_id, title, slug, parent_id, depth, 
-		menu_order, content, is_published, created_at, updated_at
-		FROM pages WHERE page_id = ?
-	`, pageID).Scan(
-		&page.PageID, &page.SiteID, &page.GroupID, &page.Title, &page.Slug,
-		&page.ParentID, &page.Depth, &page.MenuOrder, &page.Content,
-		&page.IsPublished, &page.CreatedAt, &page.UpdatedAt,
-	)
-	if err == sql.ErrNoRows {
-		http.Error(w, "Page not found", http.StatusNotFound)
+	page, err := h.pages.GetPage(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
 		return
 	}
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if !h.inPath(w, r, page.SiteID, page.GroupID, response.CodePageNotFound) {
 		return
 	}
 
-	json.NewEncoder(w).Encode(page)
+	// 공개 스냅샷은 사이트 역할이 있으면, 초안은 그룹을 볼 권한이 있어야 볼 수 있습니다.
+	published, _ := strconv.ParseBool(r.URL.Query().Get("published"))
+	action := rbac.ActionView
+	if published {
+		action = rbac.ActionAccess
+	}
+	if !authorize(w, r, action, page.SiteID, page.GroupID) {
+		return
+	}
+	if published {
+		if page = page.PublishedView(time.Now()); page == nil {
+			response.Error(w, r, response.NotFound(response.CodePageNotFound))
+			return
+		}
+	}
+	localizer, ok := h.localizer(w, r, page.SiteID, page.PageID)
+	if !ok {
+		return
+	}
+	// 번역 저장과 공개도 페이지 버전을 올리므로 버전과 응답 언어로 ETag 를 만듭니다.
+	if notModified(w, r, localeETag(versionETag(etagPage, page.PageID, page.Version), localizer.Locale)) {
+		return
+	}
+	page = localizer.Page(page, published)
+	if err := h.renderContent(r.Context(), page); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
 }
 
 // UpdatePage godoc
 // @Summary Update page
-// @Description Update an existing page with new information
+// @Description Update the title, slug, content and schedule of a page. The position (parent_id, menu_order) is changed with the move endpoint and the published state with publish and unpublish; sending those fields is rejected. HTML content is sanitized before saving when the site content policy mode is sanitize. With If-Match, the update is applied only while the page still has that ETag. publish_at and unpublish_at replace the current schedule (omitted means cleared); changing the schedule requires the publish permission.
 // @Tags pages
 // @Accept json
 // @Produce json
 // @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
 // @Param page_id path int true "Page ID"
+// @Param If-Match header string false "ETag from GetPage"
 // @Param page body models.UpdatePageInput true "Page Information"
-// @Success 200 {object} models.Page
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/sites/{site_code}/pages/{page_id} [put]
+// @Success 200 {object} response.Response{data=map[string]bool}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id} [put]
 func (h *Handler) UpdatePage(w http.ResponseWriter, r *http.Request) {
 	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
 	if err != nil {
-		http.Error(w, "Invalid page ID", http.StatusBadRequest)
+		response.Error(w, r, response.InvalidParameter("page_id"))
 		return
 	}
 
-	var input struct {
-		Title   string `json:"title"`
-		Slug    string `json:"slug"`
-		Content string `json:"content"`
+	var input models.UpdatePageInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
 	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := validSchedule(input.PublishAt, input.UnpublishAt); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
-	result, err := h.db.Exec(`
-		UPDATE pages 
-		SET title = ?, slug = ?, content = ?, updated_at = NOW()
-		WHERE page_id = ?
-	`, input.Title, input.Slug, input.Content, pageID)
+	current, ok := h.pageFor(w, r, pageID, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagPage, pageID, current.Version)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
+		return
+	}
+	if scheduleChanged(current, input.PublishAt, input.UnpublishAt) && !authorize(w, r, rbac.ActionPublish, current.SiteID, current.GroupID) {
+		return
+	}
+
+	page := &models.Page{
+		PageID:        pageID,
+		Title:         input.Title,
+		Slug:          input.Slug,
+		Content:       input.Content,
+		ContentFormat: input.ContentFormat,
+		PublishAt:     input.PublishAt,
+		UnpublishAt:   input.UnpublishAt,
+		Version:       version,
+	}
+	if page.ContentFormat == "" {
+		page.ContentFormat = current.ContentFormat
+	}
+	if err := h.cleanContent(r.Context(), current.SiteID, page); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
 		return
 	}
+	defer rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	err = h.pages.UpdatePage(r.Context(), page)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
 		return
 	}
 
-	if rowsAffected == 0 {
-		http.Error(w, "Page not found", http.StatusNotFound)
+	page, err = h.pages.GetPage(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	if !h.record(w, r, page.SiteID, models.EntityPage, page.PageID, models.AuditUpdate, current, page) {
+		return
+	}
+
+	// 다음 수정에 쓸 수 있도록 바뀐 버전의 ETag 를 돌려줍니다.
+	w.Header().Set("ETag", versionETag(etagPage, page.PageID, page.Version))
 
-	json.NewEncoder(w).Encode(map[string]bool{"updated": true})
+	response.JSON(w, http.StatusOK, map[string]bool{"updated": true})
+}
+
+// PatchPage godoc
+// @Summary Patch page
+// @Description Apply a JSON merge patch (RFC 7396) to a page. Only the fields in the patch are written; null clears a field (parent_id null moves the page to the top level, menu_order null places it last among its siblings, is_published null unpublishes it). Content changes record a new revision, parent_id and menu_order move the page like the move endpoint, and is_published true publishes the latest revision including the content in the same patch. With If-Match, the patch is applied only while the page still has that ETag. Changing is_published, publish_at or unpublish_at requires the publish permission.
+// @Tags pages
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Param If-Match header string false "ETag from GetPage"
+// @Param patch body models.PatchPageInput true "Merge patch (application/merge-patch+json)"
+// @Success 200 {object} response.Response{data=models.Page}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id} [patch]
+func (h *Handler) PatchPage(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+
+	current, ok := h.pageFor(w, r, pageID, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagPage, pageID, current.Version)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	input := models.PatchPageInput{
+		Title:         current.Title,
+		Slug:          current.Slug,
+		Content:       current.Content,
+		ContentFormat: current.ContentFormat,
+		IsPublished:   current.IsPublished,
+		ParentID:      current.ParentID,
+		MenuOrder:     current.MenuOrder,
+		PublishAt:     current.PublishAt,
+		UnpublishAt:   current.UnpublishAt,
+	}
+	fields, err := decodePatch(w, r, &input)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	publishing := fields["is_published"] || scheduleChanged(current, input.PublishAt, input.UnpublishAt)
+	if publishing && !authorize(w, r, rbac.ActionPublish, current.SiteID, current.GroupID) {
+		return
+	}
+	if err := validSchedule(input.PublishAt, input.UnpublishAt); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if fields["content"] || fields["content_format"] {
+		page := &models.Page{Content: input.Content, ContentFormat: input.ContentFormat}
+		if err := h.cleanContent(r.Context(), current.SiteID, page); err != nil {
+			response.Error(w, r, err)
+			return
+		}
+		// html 로 바꾸기만 해도 기존 본문을 정제해 저장해야 합니다.
+		if page.Content != input.Content || fields["content_format"] {
+			input.Content = page.Content
+			fields["content"] = true
+		}
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.pages.PatchPage(r.Context(), pageID, input, fields, version); err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
+		return
+	}
+
+	page, err := h.pages.GetPage(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, page.SiteID, models.EntityPage, page.PageID, patchAction(fields, input.IsPublished), current, page) {
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(etagPage, page.PageID, page.Version))
+	response.JSON(w, http.StatusOK, page)
+}
+
+// patchAction 은 PATCH 의 이력 동작입니다. is_published 를 바꾸면 공개, 공개 취소로, parent_id 나
+// menu_order 만 바꾸면 이동으로, 그 밖에는 수정으로 기록합니다.
+func patchAction(fields map[string]bool, published bool) string {
+	switch {
+	case fields["is_published"] && published:
+		return models.AuditPublish
+	case fields["is_published"]:
+		return models.AuditUnpublish
+	}
+	moved := len(fields) > 0
+	for field := range fields {
+		if field != "parent_id" && field != "menu_order" {
+			moved = false
+		}
+	}
+	if moved {
+		return models.AuditMove
+	}
+	return models.AuditUpdate
 }
 
 // DeletePage godoc
 // @Summary Delete page
-// @Description Delete a specific page
+// @Description Delete a specific page. With If-Match, the page is deleted only while it still has that ETag.
 // @Tags pages
 // @Accept json
 // @Produce json
 // @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
 // @Param page_id path int true "Page ID"
-// @Success 204
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/sites/{site_code}/pages/{page_id} [delete]
+// @Param If-Match header string false "ETag from GetPage"
+// @Success 200 {object} response.Response{data=map[string]bool}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id} [delete]
 func (h *Handler) DeletePage(w http.ResponseWriter, r *http.Request) {
 	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
 	if err != nil {
-		http.Error(w, "Invalid page ID", http.StatusBadRequest)
+		response.Error(w, r, response.InvalidParameter("page_id"))
 		return
 	}
 
-	result, err := h.db.Exec("DELETE FROM pages WHERE page_id = ?", pageID)
+	page, ok := h.pageFor(w, r, pageID, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagPage, pageID, page.Version)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
 		return
 	}
+	defer rollback()
 
-	if rowsAffected == 0 {
-		http.Error(w, "Page not found", http.StatusNotFound)
+	err = h.pages.DeletePage(r.Context(), pageID, version)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
+		return
+	}
+	if !h.record(w, r, page.SiteID, models.EntityPage, page.PageID, models.AuditDelete, page, nil) {
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]bool{"deleted": true})
+	response.JSON(w, http.StatusOK, map[string]bool{"deleted": true})
 }
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"pages/internal/models"
+	"pages/pkg/response"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodePatch(t *testing.T) {
+	parentID := 7
+	publishAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := func() models.PatchPageInput {
+		return models.PatchPageInput{
+			Title: "Title", Slug: "title", Content: "body", ContentFormat: "markdown",
+			ParentID: &parentID, MenuOrder: 2, PublishAt: &publishAt,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		code        response.Code // 오류가 없으면 ""
+		fields      []string
+		check       func(t *testing.T, got models.PatchPageInput)
+	}{
+		{
+			name: "changes only given fields", body: `{"title": "New"}`, fields: []string{"title"},
+			check: func(t *testing.T, got models.PatchPageInput) {
+				want := current()
+				want.Title = "New"
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("input = %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "null clears nullable fields", contentType: "application/merge-patch+json",
+			body: `{"parent_id": null, "publish_at": null}`, fields: []string{"parent_id", "publish_at"},
+			check: func(t *testing.T, got models.PatchPageInput) {
+				if got.ParentID != nil || got.PublishAt != nil {
+					t.Errorf("parent_id = %v, publish_at = %v, want nil", got.ParentID, got.PublishAt)
+				}
+				if got.MenuOrder != 2 || got.Title != "Title" {
+					t.Errorf("untouched fields changed: %+v", got)
+				}
+			},
+		},
+		{
+			name: "parent without menu_order", body: `{"parent_id": 3}`, fields: []string{"parent_id"},
+			check: func(t *testing.T, got models.PatchPageInput) {
+				if got.ParentID == nil || *got.ParentID != 3 {
+					t.Errorf("parent_id = %v, want 3", got.ParentID)
+				}
+			},
+		},
+		{
+			// patchPosition 은 0 을 형제 중 마지막으로 해석합니다.
+			name: "null menu_order", body: `{"menu_order": null}`, fields: []string{"menu_order"},
+			check: func(t *testing.T, got models.PatchPageInput) {
+				if got.MenuOrder != 0 {
+					t.Errorf("menu_order = %d, want 0", got.MenuOrder)
+				}
+			},
+		},
+		{name: "empty patch", body: `{}`, fields: []string{}},
+		{name: "null required field", body: `{"title": null}`, code: response.CodeValidationFailed},
+		{name: "unknown field", body: `{"titel": "typo"}`, code: response.CodeInvalidBody},
+		{name: "read-only field", body: `{"version": 3}`, code: response.CodeInvalidBody},
+		{name: "wrong type", body: `{"menu_order": "1"}`, code: response.CodeInvalidBody},
+		{name: "not an object", body: `[]`, code: response.CodeInvalidBody},
+		{name: "malformed", body: `{"title":`, code: response.CodeInvalidBody},
+		{name: "json patch media type", contentType: "application/json-patch+json", body: `[]`, code: response.CodeUnsupportedMedia},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("PATCH", "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			input := current()
+			fields, err := decodePatch(httptest.NewRecorder(), r, &input)
+
+			if tt.code != "" {
+				var apiErr *response.APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != tt.code {
+					t.Fatalf("err = %v, want %s", err, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if len(fields) != len(tt.fields) {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+			for _, name := range tt.fields {
+				if !fields[name] {
+					t.Errorf("fields = %v, want %v", fields, tt.fields)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, input)
+			}
+		})
+	}
+}
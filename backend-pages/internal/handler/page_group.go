@@ -1,10 +1,11 @@
 package handler
 
 import (
-	"database/sql"
-	"encoding/json"
 	"net/http"
 	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/store"
+	"pages/pkg/response"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -12,51 +13,93 @@ import (
 
 // GetPageGroups godoc
 // @Summary 페이지 그룹 목록 조회
-// @Description 사이트에 등록된 모든 페이지 그룹의 목록을 조회합니다.
+// @Description 사이트에 등록된 페이지 그룹 중 볼 권한이 있는 그룹 목록을 limit 개씩 이름순(sort 로 변경)으로 조회합니다. meta.next_cursor 가 있으면 cursor 로 넘겨 다음 목록을 가져옵니다. ETag 는 목록에 포함된 그룹과 버전으로 만들며, If-None-Match 가 같으면 304 를 반환합니다.
 // @Tags page_groups
 // @Accept json
 // @Produce json
 // @Param site_code path string true "사이트 코드"
-// @Success 200 {array} models.PageGroup
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param limit query int false "최대 결과 수 (기본 50, 최대 200)"
+// @Param cursor query string false "이전 응답의 meta.next_cursor"
+// @Param sort query string false "정렬 (name, created_at, updated_at, 앞에 - 를 붙이면 내림차순, 쉼표로 여러 개)"
+// @Param name query string false "이름에 포함된 문자열"
+// @Param updated_since query string false "이 시각(RFC 3339 또는 YYYY-MM-DD) 이후 수정(또는 생성)된 그룹"
+// @Param If-None-Match header string false "캐시한 응답의 ETag"
+// @Success 200 {object} response.Response{data=[]models.PageGroup,meta=response.Meta}
+// @Success 304
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites/{site_code}/groups [get]
 func (h *Handler) GetPageGroups(w http.ResponseWriter, r *http.Request) {
-	siteCode := chi.URLParam(r, "siteCode")
-
 	// 사이트 ID 조회
-	var siteID int
-	err := h.db.QueryRow("SELECT site_id FROM sites WHERE code = ?", siteCode).Scan(&siteID)
-	if err == sql.ErrNoRows {
-		http.Error(w, "사이트를 찾을 수 없습니다", http.StatusNotFound)
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
 		return
-	} else if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+
+	q, err := listQuery(r, store.PageGroupList)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	// 사이트 역할 없이 그룹 역할만 있으면 그 그룹만 보여 줍니다.
+	if perms := rbac.FromContext(r.Context()); !perms.Can(rbac.ActionView, site.SiteID, 0) {
+		q.Restrict("group_id", perms.GroupIDs(site.SiteID))
+	}
 
 	// 페이지 그룹 조회
-	rows, err := h.db.Query(
-		"SELECT group_id, site_id, name, description, created_at, updated_at FROM page_groups WHERE site_id = ? ORDER BY name",
-		siteID,
-	)
+	groups, next, err := h.groups.QueryPageGroups(r.Context(), site.SiteID, q)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, storeError(err, response.CodeGroupNotFound))
 		return
 	}
-	defer rows.Close()
 
-	var groups []models.PageGroup
-	for rows.Next() {
-		var group models.PageGroup
-		if err := rows.Scan(&group.GroupID, &group.SiteID, &group.Name, &group.Description, &group.CreatedAt, &group.UpdatedAt); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		groups = append(groups, group)
+	parts := []interface{}{next}
+	for _, group := range groups {
+		parts = append(parts, group.GroupID, group.Version)
+	}
+	if notModified(w, r, listETag(parts...)) {
+		return
 	}
 
-	json.NewEncoder(w).Encode(groups)
+	response.List(w, groups, next)
+}
+
+// GetPageGroup godoc
+// @Summary 페이지 그룹 조회
+// @Description 페이지 그룹을 조회합니다. ETag 헤더의 값을 수정, 삭제 요청의 If-Match 로 보내면 그 사이 다른 요청이 그룹을 바꿨을 때 412 를 반환합니다.
+// @Tags page_groups
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param group_id path int true "Group ID"
+// @Param If-None-Match header string false "캐시한 응답의 ETag"
+// @Success 200 {object} response.Response{data=models.PageGroup}
+// @Success 304
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id} [get]
+func (h *Handler) GetPageGroup(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupId"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("group_id"))
+		return
+	}
+
+	group, ok := h.groupFor(w, r, groupId, rbac.ActionView)
+	if !ok {
+		return
+	}
+	if notModified(w, r, versionETag(etagGroup, group.GroupID, group.Version)) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, group)
 }
 
 // CreatePageGroup godoc
@@ -65,49 +108,49 @@ func (h *Handler) GetPageGroups(w http.ResponseWriter, r *http.Request) {
 // @Tags page_groups
 // @Accept json
 // @Produce json
-// @Param siteCode path string true "사이트 코드"
+// @Param site_code path string true "사이트 코드"
 // @Param input body models.CreatePageGroupInput true "페이지 그룹 생성 입력"
-// @Success 201 {object} models.PageGroup
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/sites/{siteCode}/groups [post]
+// @Success 201 {object} response.Response{data=models.PageGroup}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups [post]
 func (h *Handler) CreatePageGroup(w http.ResponseWriter, r *http.Request) {
-	siteCode := chi.URLParam(r, "siteCode")
-
 	// 사이트 ID 조회
-	var siteID int
-	err := h.db.QueryRow("SELECT site_id FROM sites WHERE code = ?", siteCode).Scan(&siteID)
-	if err == sql.ErrNoRows {
-		http.Error(w, "사이트를 찾을 수 없습니다", http.StatusNotFound)
-		return
-	} else if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
 		return
 	}
 
 	var input models.CreatePageGroupInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
-	result, err := h.db.Exec(
-		"INSERT INTO page_groups (site_id, name, description) VALUES (?, ?, ?)",
-		siteID, input.Name, input.Description,
-	)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
 		return
 	}
+	defer rollback()
 
-	id, err := result.LastInsertId()
+	id, err := h.groups.CreatePageGroup(r.Context(), site.SiteID, input)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
+	}
+	group, err := h.groups.GetPageGroup(r.Context(), int(id))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntityPageGroup, group.GroupID, models.AuditCreate, nil, group) {
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	response.JSON(w, http.StatusCreated, map[string]interface{}{
 		"created":  true,
 		"group_id": id,
 	})
@@ -115,89 +158,187 @@ func (h *Handler) CreatePageGroup(w http.ResponseWriter, r *http.Request) {
 
 // UpdatePageGroup godoc
 // @Summary 페이지 그룹 업데이트
-// @Description 사이트의 페이지 그룹 정보를 업데이트합니다.
+// @Description 사이트의 페이지 그룹 정보를 업데이트합니다. If-Match 가 있으면 그룹이 아직 그 ETag 일 때만 반영합니다.
 // @Tags page_groups
 // @Accept json
 // @Produce json
 // @Param site_code path string true "사이트 코드"
 // @Param group_id path int true "Group ID"
+// @Param If-Match header string false "GetPageGroup 의 ETag"
 // @Param input body models.UpdatePageGroupInput true "Page Group Update Input"
-// @Success 200 {object} map[string]bool
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} response.Response{data=map[string]bool}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites/{site_code}/groups/{group_id} [put]
 func (h *Handler) UpdatePageGroup(w http.ResponseWriter, r *http.Request) {
 	groupId, err := strconv.Atoi(chi.URLParam(r, "groupId"))
 	if err != nil {
-		http.Error(w, "Invalid group ID", http.StatusBadRequest)
+		response.Error(w, r, response.InvalidParameter("group_id"))
+		return
+	}
+
+	before, ok := h.groupFor(w, r, groupId, rbac.ActionManage)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagGroup, groupId, before.Version)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
 	var input models.UpdatePageGroupInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
 		return
 	}
+	defer rollback()
 
-	result, err := h.db.Exec(
-		"UPDATE page_groups SET name = ?, description = ? WHERE group_id = ?",
-		input.Name, input.Description, groupId,
-	)
+	err = h.groups.UpdatePageGroup(r.Context(), groupId, input, version)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, storeError(err, response.CodeGroupNotFound))
 		return
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	group, err := h.groups.GetPageGroup(r.Context(), groupId)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, group.SiteID, models.EntityPageGroup, group.GroupID, models.AuditUpdate, before, group) {
 		return
 	}
 
-	if rowsAffected == 0 {
-		http.Error(w, "페이지 그룹을 찾을 수 없습니다", http.StatusNotFound)
+	w.Header().Set("ETag", versionETag(etagGroup, group.GroupID, group.Version))
+
+	response.JSON(w, http.StatusOK, map[string]bool{"updated": true})
+}
+
+// PatchPageGroup godoc
+// @Summary 페이지 그룹 부분 수정
+// @Description JSON merge patch(RFC 7396)로 페이지 그룹의 name, description 을 수정합니다. patch 에 있는 필드만 저장합니다. If-Match 가 있으면 그룹이 아직 그 ETag 일 때만 반영합니다.
+// @Tags page_groups
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param group_id path int true "Group ID"
+// @Param If-Match header string false "GetPageGroup 의 ETag"
+// @Param patch body models.PatchPageGroupInput true "Merge patch (application/merge-patch+json)"
+// @Success 200 {object} response.Response{data=models.PageGroup}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id} [patch]
+func (h *Handler) PatchPageGroup(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupId"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("group_id"))
+		return
+	}
+
+	group, ok := h.groupFor(w, r, groupId, rbac.ActionManage)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagGroup, groupId, group.Version)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]bool{"updated": true})
+	input := models.PatchPageGroupInput{Name: group.Name, Description: group.Description}
+	fields, err := decodePatch(w, r, &input)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.groups.PatchPageGroup(r.Context(), groupId, input, fields, version); err != nil {
+		response.Error(w, r, storeError(err, response.CodeGroupNotFound))
+		return
+	}
+
+	before := group
+	group, err = h.groups.GetPageGroup(r.Context(), groupId)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, group.SiteID, models.EntityPageGroup, group.GroupID, models.AuditUpdate, before, group) {
+		return
+	}
+
+	w.Header().Set("ETag", versionETag(etagGroup, group.GroupID, group.Version))
+	response.JSON(w, http.StatusOK, group)
 }
 
 // DeletePageGroup godoc
 // @Summary 페이지 그룹 삭제
-// @Description 사이트의 페이지 그룹을 삭제합니다.
+// @Description 사이트의 페이지 그룹을 삭제합니다. If-Match 가 있으면 그룹이 아직 그 ETag 일 때만 삭제합니다.
 // @Tags page_groups
 // @Accept json
 // @Produce json
 // @Param site_code path string true "사이트 코드"
 // @Param group_id path int true "Group ID"
+// @Param If-Match header string false "GetPageGroup 의 ETag"
 // @Success 204
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
 // @Router /api/sites/{site_code}/groups/{group_id} [delete]
 func (h *Handler) DeletePageGroup(w http.ResponseWriter, r *http.Request) {
 	groupId, err := strconv.Atoi(chi.URLParam(r, "groupId"))
 	if err != nil {
-		http.Error(w, "Invalid group ID", http.StatusBadRequest)
+		response.Error(w, r, response.InvalidParameter("group_id"))
 		return
 	}
 
-	result, err := h.db.Exec("DELETE FROM page_groups WHERE group_id = ?", groupId)
+	group, ok := h.groupFor(w, r, groupId, rbac.ActionManage)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagGroup, groupId, group.Version)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
 		return
 	}
+	defer rollback()
 
-	if rowsAffected == 0 {
-		http.Error(w, "페이지 그룹을 찾을 수 없습니다", http.StatusNotFound)
+	err = h.groups.DeletePageGroup(r.Context(), groupId, version)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeGroupNotFound))
+		return
+	}
+	if !h.record(w, r, group.SiteID, models.EntityPageGroup, group.GroupID, models.AuditDelete, group, nil) {
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]bool{"deleted": true})
+	response.JSON(w, http.StatusOK, map[string]bool{"deleted": true})
 }
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/pagetree"
+	"pages/internal/rbac"
+	"pages/pkg/response"
+	"strconv"
+	"time"
+)
+
+// ResolvePage godoc
+// @Summary slug 경로로 페이지 조회
+// @Description 사이트의 최상위 페이지부터 slug 경로를 따라 내려가 페이지와 breadcrumb 를 조회합니다. 페이지의 content_html 에는 content_format 에 따라 렌더링하고 정제한 본문이 담깁니다. 기본적으로 공개 중인 페이지의 공개 스냅샷만 대상으로 하며, preview=true 이면 초안 slug 로 조회하며, 사이트 전체를 볼 권한(viewer 이상)이 필요합니다. slug 와 제목은 요청 언어(locale 또는 Accept-Language)의 번역을 사이트 fallback 순서에 따라 적용한 값입니다. 경로가 끊기면 404 와 함께 가장 깊이 일치한 조상을 반환합니다.
+// @Tags pages
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param path query string true "slug 경로 (예: /service/pricing)"
+// @Param preview query bool false "초안 미리보기"
+// @Param locale query string false "언어 (생략하면 Accept-Language)"
+// @Param Accept-Language header string false "요청 언어"
+// @Success 200 {object} response.Response{data=models.ResolveResult}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response{data=models.ResolveResult}
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/resolve [get]
+func (h *Handler) ResolvePage(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	if len(pagetree.Segments(path)) == 0 {
+		response.Error(w, r, response.InvalidParameter("path"))
+		return
+	}
+	preview, _ := strconv.ParseBool(r.URL.Query().Get("preview"))
+
+	// 초안 경로는 모든 그룹의 초안을 거치므로 사이트 전체를 볼 권한이 필요합니다.
+	action := rbac.ActionAccess
+	if preview {
+		action = rbac.ActionView
+	}
+	site, ok := h.siteFor(w, r, action)
+	if !ok {
+		return
+	}
+
+	pages, err := h.pages.ListSitePages(r.Context(), site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !preview {
+		pages = pagetree.Published(pages, time.Now())
+	}
+	localizer, ok := h.localizer(w, r, site.SiteID, 0)
+	if !ok {
+		return
+	}
+	pages = localizer.Pages(pages, !preview)
+
+	trail, found := pagetree.Resolve(pages, path)
+	result := models.ResolveResult{
+		Path:       path,
+		Breadcrumb: pagetree.Breadcrumbs(trail),
+	}
+	if !found {
+		if len(result.Breadcrumb) > 0 {
+			result.DeepestMatch = &result.Breadcrumb[len(result.Breadcrumb)-1]
+		}
+		response.ErrorData(w, r, response.NotFound(response.CodePageNotFound), result)
+		return
+	}
+
+	result.Page = trail[len(trail)-1]
+	if err := h.renderContent(r.Context(), result.Page); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/pkg/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListRevisions godoc
+// @Summary 페이지 리비전 목록 조회
+// @Description 페이지의 리비전을 최신순으로 조회합니다.
+// @Tags revisions
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Success 200 {object} response.Response{data=[]models.PageRevision}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/revisions [get]
+func (h *Handler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+
+	if _, ok := h.pageFor(w, r, pageID, rbac.ActionView); !ok {
+		return
+	}
+
+	revisions, err := h.pages.ListRevisions(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, revisions)
+}
+
+// GetRevision godoc
+// @Summary 페이지 리비전 조회
+// @Description 페이지의 특정 리비전을 조회합니다.
+// @Tags revisions
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Param rev path int true "Revision"
+// @Success 200 {object} response.Response{data=models.PageRevision}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/revisions/{rev} [get]
+func (h *Handler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+	rev, err := strconv.Atoi(chi.URLParam(r, "rev"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("rev"))
+		return
+	}
+
+	if _, ok := h.pageFor(w, r, pageID, rbac.ActionView); !ok {
+		return
+	}
+
+	revision, err := h.pages.GetRevision(r.Context(), pageID, rev)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeRevisionNotFound))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, revision)
+}
+
+// DiffRevisions godoc
+// @Summary 페이지 리비전 비교
+// @Description 두 리비전 사이에서 값이 달라진 필드(title, slug, content, content_format)를 조회합니다.
+// @Tags revisions
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Param from query int true "기준 리비전"
+// @Param to query int true "비교 리비전"
+// @Success 200 {object} response.Response{data=models.RevisionDiff}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/revisions/diff [get]
+func (h *Handler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+	fromRev, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("from"))
+		return
+	}
+	toRev, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("to"))
+		return
+	}
+
+	if _, ok := h.pageFor(w, r, pageID, rbac.ActionView); !ok {
+		return
+	}
+
+	var revisions [2]*models.PageRevision
+	for i, rev := range []int{fromRev, toRev} {
+		revisions[i], err = h.pages.GetRevision(r.Context(), pageID, rev)
+		if err != nil {
+			response.Error(w, r, storeError(err, response.CodeRevisionNotFound))
+			return
+		}
+	}
+
+	response.JSON(w, http.StatusOK, diffRevisions(revisions[0], revisions[1]))
+}
+
+// RestoreRevision godoc
+// @Summary 페이지 리비전 복원
+// @Description 지정한 리비전의 title, slug, content, content_format 으로 페이지를 되돌립니다. 복원 결과는 새 리비전으로 기록됩니다.
+// @Tags revisions
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Param rev path int true "Revision"
+// @Success 200 {object} response.Response{data=models.Page}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/revisions/{rev}/restore [post]
+func (h *Handler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+	rev, err := strconv.Atoi(chi.URLParam(r, "rev"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("rev"))
+		return
+	}
+
+	current, ok := h.pageFor(w, r, pageID, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+
+	revision, err := h.pages.GetRevision(r.Context(), pageID, rev)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeRevisionNotFound))
+		return
+	}
+
+	// 공개/만료 예약은 리비전에 없으므로 현재 값을 그대로 둡니다. 복원은 edit 권한이라 예약을 바꿀 수 없습니다.
+	page := &models.Page{
+		PageID:        pageID,
+		Title:         revision.Title,
+		Slug:          revision.Slug,
+		Content:       revision.Content,
+		ContentFormat: revision.ContentFormat,
+		PublishAt:     current.PublishAt,
+		UnpublishAt:   current.UnpublishAt,
+		Version:       current.Version,
+	}
+	// 정책을 바꾸기 전에 기록된 리비전일 수 있으므로 현재 정책으로 다시 정제합니다.
+	if err := h.cleanContent(r.Context(), current.SiteID, page); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	err = h.pages.UpdatePage(r.Context(), page)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
+		return
+	}
+
+	page, err = h.pages.GetPage(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, page.SiteID, models.EntityPage, page.PageID, models.AuditUpdate, current, page) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
+}
+
+// diffRevisions 는 from 과 to 사이에서 값이 달라진 필드를 반환합니다.
+func diffRevisions(from, to *models.PageRevision) models.RevisionDiff {
+	diff := models.RevisionDiff{
+		PageID:  to.PageID,
+		From:    from.Revision,
+		To:      to.Revision,
+		Changes: []models.FieldChange{},
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"title", from.Title, to.Title},
+		{"slug", from.Slug, to.Slug},
+		{"content", from.Content, to.Content},
+		{"content_format", from.ContentFormat, to.ContentFormat},
+	}
+	for _, f := range fields {
+		if f.from != f.to {
+			diff.Changes = append(diff.Changes, models.FieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/pkg/response"
+	"strconv"
+)
+
+// 검색 결과 개수 기본값과 최댓값
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchSite godoc
+// @Summary 사이트 페이지 검색
+// @Description 사이트 페이지의 제목과 본문을 검색해 점수 순으로 반환합니다. 검색어는 모두 단어의 접두어로 일치해야 하며, 제목 일치에 가중치가 있습니다. title, snippet 은 검색어를 <mark> 로 감싼 HTML 입니다. published=true 이면 공개 중인 페이지의 공개 스냅샷을, published=false 이면 공개 중이 아닌 페이지의 초안을, 생략하면 모든 페이지의 초안을 검색합니다. 공개 스냅샷은 사이트 역할이 있으면, 초안은 group_id 그룹(생략하면 사이트 전체)을 볼 권한이 있어야 검색할 수 있습니다. 번역(page_translations)은 검색하지 않고 사이트 기본 언어의 원문만 검색합니다. MySQL 에서는 3글자보다 짧은 검색어는 접두어 대신 제목이나 본문에 포함되어 있으면 일치합니다.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param q query string true "검색어"
+// @Param group_id query int false "Group ID"
+// @Param published query bool false "공개 상태"
+// @Param limit query int false "최대 결과 수 (기본 20, 최대 100)"
+// @Param offset query int false "건너뛸 결과 수"
+// @Success 200 {object} response.Response{data=models.SearchResult}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/search [get]
+func (h *Handler) SearchSite(w http.ResponseWriter, r *http.Request) {
+	q, err := searchQuery(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	action := rbac.ActionView
+	if q.Published != nil && *q.Published {
+		action = rbac.ActionAccess
+	}
+	site, ok := h.siteFromPath(w, r)
+	if !ok || !authorize(w, r, action, site.SiteID, q.GroupID) {
+		return
+	}
+	q.SiteID = site.SiteID
+
+	result, err := h.search.Search(r.Context(), q)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// Search godoc
+// @Summary 전체 사이트 페이지 검색
+// @Description 모든 사이트의 페이지를 검색합니다. 조건과 결과 형식은 사이트 검색과 같습니다. super-admin 권한이 필요합니다.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "검색어"
+// @Param group_id query int false "Group ID"
+// @Param published query bool false "공개 상태"
+// @Param limit query int false "최대 결과 수 (기본 20, 최대 100)"
+// @Param offset query int false "건너뛸 결과 수"
+// @Success 200 {object} response.Response{data=models.SearchResult}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/search [get]
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	q, err := searchQuery(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	result, err := h.search.Search(r.Context(), q)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// searchQuery 는 검색 쿼리 파라미터를 읽습니다. 잘못된 값은 *response.APIError 로 반환합니다.
+func searchQuery(r *http.Request) (models.SearchQuery, error) {
+	params := r.URL.Query()
+	q := models.SearchQuery{Text: params.Get("q"), Limit: defaultSearchLimit}
+	if q.Text == "" {
+		return q, response.InvalidParameter("q")
+	}
+
+	var err error
+	if value := params.Get("group_id"); value != "" {
+		if q.GroupID, err = strconv.Atoi(value); err != nil {
+			return q, response.InvalidParameter("group_id")
+		}
+	}
+	if value := params.Get("published"); value != "" {
+		published, err := strconv.ParseBool(value)
+		if err != nil {
+			return q, response.InvalidParameter("published")
+		}
+		q.Published = &published
+	}
+	if value := params.Get("limit"); value != "" {
+		if q.Limit, err = strconv.Atoi(value); err != nil || q.Limit < 1 {
+			return q, response.InvalidParameter("limit")
+		}
+		if q.Limit > maxSearchLimit {
+			q.Limit = maxSearchLimit
+		}
+	}
+	if value := params.Get("offset"); value != "" {
+		if q.Offset, err = strconv.Atoi(value); err != nil || q.Offset < 0 {
+			return q, response.InvalidParameter("offset")
+		}
+	}
+	return q, nil
+}
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/pkg/response"
+)
+
+// GetSite godoc
+// @Summary 사이트 조회
+// @Description 사이트를 조회합니다. aliases 는 바꾸기 전의 코드이며, 옛 코드로 요청하면 현재 코드의 같은 경로로 리다이렉트(308)합니다. ETag 헤더의 값을 수정, 삭제 요청의 If-Match 로 보낼 수 있습니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param If-None-Match header string false "캐시한 응답의 ETag"
+// @Success 200 {object} response.Response{data=models.Site}
+// @Success 304
+// @Success 308
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code} [get]
+func (h *Handler) GetSite(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+	if notModified(w, r, versionETag(etagSite, site.SiteID, site.Version)) {
+		return
+	}
+
+	h.writeSite(w, r, http.StatusOK, site)
+}
+
+// writeSite 는 별칭을 채운 사이트와 ETag 를 응답합니다.
+func (h *Handler) writeSite(w http.ResponseWriter, r *http.Request, status int, site *models.Site) {
+	aliases, err := h.sites.ListSiteAliases(r.Context(), site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	site.Aliases = aliases
+
+	w.Header().Set("ETag", versionETag(etagSite, site.SiteID, site.Version))
+	response.JSON(w, status, site)
+}
+
+// UpdateSite godoc
+// @Summary 사이트 수정
+// @Description 사이트의 name, domain 을 바꿉니다. 코드는 rename 으로 바꿉니다. If-Match 가 있으면 사이트가 아직 그 ETag 일 때만 반영합니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param If-Match header string false "GetSite 의 ETag"
+// @Param site body models.UpdateSiteInput true "사이트 정보"
+// @Success 200 {object} response.Response{data=models.Site}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code} [put]
+func (h *Handler) UpdateSite(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagSite, site.SiteID, site.Version)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	var input models.UpdateSiteInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.sites.UpdateSite(r.Context(), site.SiteID, input, version); err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
+	}
+
+	before := site
+	site, err = h.sites.GetSiteByCode(r.Context(), site.Code)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntitySite, site.SiteID, models.AuditUpdate, before, site) {
+		return
+	}
+
+	h.writeSite(w, r, http.StatusOK, site)
+}
+
+// PatchSite godoc
+// @Summary 사이트 부분 수정
+// @Description JSON merge patch(RFC 7396)로 사이트의 name, domain 을 수정합니다. patch 에 있는 필드만 저장하며 domain 을 null 로 보내면 지웁니다. If-Match 가 있으면 사이트가 아직 그 ETag 일 때만 반영합니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param If-Match header string false "GetSite 의 ETag"
+// @Param patch body models.PatchSiteInput true "Merge patch (application/merge-patch+json)"
+// @Success 200 {object} response.Response{data=models.Site}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code} [patch]
+func (h *Handler) PatchSite(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagSite, site.SiteID, site.Version)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	input := models.PatchSiteInput{Name: site.Name, Domain: site.Domain}
+	fields, err := decodePatch(w, r, &input)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.sites.PatchSite(r.Context(), site.SiteID, input, fields, version); err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
+	}
+
+	before := site
+	site, err = h.sites.GetSiteByCode(r.Context(), site.Code)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntitySite, site.SiteID, models.AuditUpdate, before, site) {
+		return
+	}
+
+	h.writeSite(w, r, http.StatusOK, site)
+}
+
+// RenameSite godoc
+// @Summary 사이트 코드 변경
+// @Description 사이트 코드를 바꿉니다. 이전 코드는 별칭으로 남아 옛 코드로 온 요청을 새 코드의 같은 경로로 리다이렉트(308)합니다. 다른 사이트의 코드나 별칭은 쓸 수 없으며(409), 이 사이트의 별칭으로 바꾸면 그 별칭을 지우고 되돌립니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param If-Match header string false "GetSite 의 ETag"
+// @Param input body models.RenameSiteInput true "새 사이트 코드"
+// @Success 200 {object} response.Response{data=models.Site}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/rename [post]
+func (h *Handler) RenameSite(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagSite, site.SiteID, site.Version)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	var input models.RenameSiteInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.sites.RenameSite(r.Context(), site.SiteID, input.Code, version); err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
+	}
+
+	before := site
+	site, err = h.sites.GetSiteByCode(r.Context(), input.Code)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntitySite, site.SiteID, models.AuditUpdate, before, site) {
+		return
+	}
+
+	w.Header().Set("Location", "/api/sites/"+site.Code)
+	h.writeSite(w, r, http.StatusOK, site)
+}
+
+// DeleteSite godoc
+// @Summary 사이트 삭제
+// @Description 사이트와 그 그룹, 페이지, 리비전, 번역, 설정을 모두 삭제합니다. 실수로 지우지 않도록 confirm 에 사이트 코드를 보내야 하며, 없거나 다르면 함께 지워질 그룹과 페이지 수를 error.details 에 담아 400 CONFIRMATION_REQUIRED 를 반환합니다. 삭제하면 지운 그룹과 페이지 수를 반환합니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "사이트 코드"
+// @Param confirm query string true "삭제 확인용 사이트 코드"
+// @Param If-Match header string false "GetSite 의 ETag"
+// @Success 200 {object} response.Response{data=models.SiteDeletion}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 412 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code} [delete]
+func (h *Handler) DeleteSite(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+	version, err := ifMatch(r, etagSite, site.SiteID, site.Version)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if r.URL.Query().Get("confirm") != site.Code {
+		counts, err := h.sites.CountSiteContents(r.Context(), site.SiteID)
+		if err != nil {
+			response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+			return
+		}
+		apiErr := response.NewError(http.StatusBadRequest, response.CodeConfirmRequired, site.Code)
+		apiErr.Details = counts
+		response.Error(w, r, apiErr)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	deletion, err := h.sites.DeleteSite(r.Context(), site.SiteID, version)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntitySite, site.SiteID, models.AuditDelete, site, nil) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, deletion)
+}
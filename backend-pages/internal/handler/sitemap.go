@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/sitemap"
+	"pages/internal/store"
+	"pages/pkg/response"
+)
+
+// GetSitemap godoc
+// @Summary 사이트 sitemap.xml 조회
+// @Description 공개 중인 페이지로 sitemap.xml 을 만듭니다. lastmod 는 공개 시각(published_at), priority 는 depth 로 정합니다. URL 이 50,000 개를 넘으면 sitemap index 를 반환하며 각 조각은 page 로 조회합니다. 사이트 공개 호스트의 /sitemap.xml 과 같은 문서입니다.
+// @Tags sites
+// @Produce xml
+// @Param site_code path string true "Site Code"
+// @Param page query int false "sitemap index 조각 번호 (1부터)"
+// @Success 200 {string} string "sitemap.xml"
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/sitemap.xml [get]
+func (h *Handler) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+	sitemap.Serve(w, r, h.pages, site)
+}
+
+// GetRobots godoc
+// @Summary 사이트 robots.txt 조회
+// @Description 사이트의 robots.txt 를 조회합니다. 저장된 내용이 없으면 모든 경로를 허용하고 sitemap 위치를 알리는 기본값을 반환합니다. 사이트 공개 호스트의 /robots.txt 와 같은 내용입니다.
+// @Tags sites
+// @Produce plain
+// @Param site_code path string true "Site Code"
+// @Success 200 {string} string "robots.txt"
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/robots.txt [get]
+func (h *Handler) GetRobots(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+	sitemap.ServeRobots(w, r, h.sites, site)
+}
+
+// SaveRobots godoc
+// @Summary 사이트 robots.txt 저장
+// @Description 사이트의 robots.txt 내용을 저장합니다. 내용이 비어 있으면 저장된 내용을 지우고 기본값으로 되돌립니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param robots body models.SaveSiteRobotsInput true "robots.txt"
+// @Success 200 {object} response.Response{data=models.SiteRobots}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/robots.txt [put]
+func (h *Handler) SaveRobots(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+
+	var input models.SaveSiteRobotsInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	// 이력에 남길 이전 robots.txt 입니다. 없으면(ErrNotFound) nil 입니다.
+	before, _ := h.sites.GetSiteRobots(r.Context(), site.SiteID)
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.sites.SaveSiteRobots(r.Context(), site.SiteID, input.Content); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	robots, err := h.sites.GetSiteRobots(r.Context(), site.SiteID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		response.Error(w, r, err)
+		return
+	}
+	// 빈 내용을 저장하면 robots.txt 가 지워지고 기본값으로 응답합니다. 없던 것을 지운 경우는 남기지 않습니다.
+	if before != nil || robots != nil {
+		if !h.record(w, r, site.SiteID, models.EntitySiteRobots, site.SiteID, saveAction(before != nil, robots != nil), before, robots) {
+			return
+		}
+	}
+	if robots == nil {
+		robots = &models.SiteRobots{SiteID: site.SiteID, Content: sitemap.Robots(sitemap.BaseURL(site))}
+	}
+
+	response.JSON(w, http.StatusOK, robots)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/render"
+	"pages/internal/store"
+	"pages/pkg/response"
+)
+
+// GetSiteTheme godoc
+// @Summary 사이트 테마 조회
+// @Description 공개 렌더링 서버가 사용하는 사이트의 html/template 테마를 조회합니다. 저장된 테마가 없으면 기본 테마를 반환합니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Success 200 {object} response.Response{data=models.SiteTheme}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/theme [get]
+func (h *Handler) GetSiteTheme(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+
+	theme, err := h.sites.GetSiteTheme(r.Context(), site.SiteID)
+	if errors.Is(err, store.ErrNotFound) {
+		theme = &models.SiteTheme{SiteID: site.SiteID, Template: render.DefaultTheme}
+	} else if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, theme)
+}
+
+// SaveSiteTheme godoc
+// @Summary 사이트 테마 저장
+// @Description 사이트의 html/template 테마를 저장합니다. 템플릿에는 Site, Page, Content, Menu, Breadcrumb, NotFound 값이 전달됩니다. 파싱할 수 없는 템플릿은 저장하지 않습니다.
+// @Tags sites
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param theme body models.SaveSiteThemeInput true "테마"
+// @Success 200 {object} response.Response{data=models.SiteTheme}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/theme [put]
+func (h *Handler) SaveSiteTheme(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+
+	var input models.SaveSiteThemeInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if err := render.ValidateTheme(input.Template); err != nil {
+		response.Error(w, r, response.Validation("invalid template: "+err.Error()))
+		return
+	}
+
+	// 이력에 남길 이전 테마입니다. 없으면(ErrNotFound) nil 입니다.
+	before, _ := h.sites.GetSiteTheme(r.Context(), site.SiteID)
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.sites.SaveSiteTheme(r.Context(), site.SiteID, input.Template); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	theme, err := h.sites.GetSiteTheme(r.Context(), site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntitySiteTheme, site.SiteID, saveAction(before != nil, true), before, theme) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, theme)
+}
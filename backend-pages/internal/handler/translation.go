@@ -0,0 +1,421 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/i18n"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/validate"
+	"pages/pkg/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetSiteLocales godoc
+// @Summary 사이트 언어 설정 조회
+// @Description 사이트의 기본 언어, 지원 언어, 언어별 fallback 순서를 조회합니다. 저장된 설정이 없으면 기본값(ko, 지원 언어 ko, en)을 반환합니다.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Success 200 {object} response.Response{data=models.SiteLocales}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/locales [get]
+func (h *Handler) GetSiteLocales(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+
+	settings, err := i18n.Load(r.Context(), h.sites, site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, settings)
+}
+
+// SaveSiteLocales godoc
+// @Summary 사이트 언어 설정 저장
+// @Description 사이트 언어 설정을 저장합니다. 페이지 원문은 default_locale 의 내용으로 취급합니다. fallbacks 는 언어별로 번역이 없을 때 시도할 언어 목록이며, 목록이 끝나면 원문을 씁니다. 언어 태그는 모두 locales 에 있어야 합니다.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param locales body models.SiteLocales true "언어 설정"
+// @Success 200 {object} response.Response{data=models.SiteLocales}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/locales [put]
+func (h *Handler) SaveSiteLocales(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionManage)
+	if !ok {
+		return
+	}
+
+	var settings models.SiteLocales
+	if err := decode(w, r, &settings); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if err := i18n.ValidateLocales(&settings); err != nil {
+		response.Error(w, r, response.Validation(err.Error()))
+		return
+	}
+
+	// 이력에 남길 이전 언어 설정입니다. 없으면(ErrNotFound) nil 입니다.
+	before, _ := h.sites.GetSiteLocales(r.Context(), site.SiteID)
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.sites.SaveSiteLocales(r.Context(), site.SiteID, settings); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	saved, err := h.sites.GetSiteLocales(r.Context(), site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, site.SiteID, models.EntitySiteLocales, site.SiteID, saveAction(before != nil, true), before, saved) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, saved)
+}
+
+// ListTranslations godoc
+// @Summary 페이지 번역 목록 조회
+// @Description 페이지의 언어별 번역 초안과 공개 스냅샷을 조회합니다.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Success 200 {object} response.Response{data=[]models.PageTranslation}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/translations [get]
+func (h *Handler) ListTranslations(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+
+	if _, ok := h.pageFor(w, r, pageID, rbac.ActionView); !ok {
+		return
+	}
+
+	translations, err := h.pages.ListTranslations(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, translations)
+}
+
+// SaveTranslation godoc
+// @Summary 페이지 번역 저장
+// @Description 페이지의 locale 번역 초안을 저장합니다. 기본 언어의 내용은 페이지 자체를 수정합니다. 번역은 페이지를 다시 공개할 때 함께 공개됩니다. html 형식 본문은 사이트 본문 정책이 sanitize 모드이면 저장 전에 정제됩니다.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Param locale path string true "언어 태그"
+// @Param translation body models.SaveTranslationInput true "번역"
+// @Success 200 {object} response.Response{data=models.PageTranslation}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/translations/{locale} [put]
+func (h *Handler) SaveTranslation(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+
+	var input models.SaveTranslationInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if input.ContentFormat == "" {
+		input.ContentFormat = models.ContentFormatHTML
+	}
+
+	site, ok := h.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	current, ok := h.pageFor(w, r, pageID, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+	locale, ok := h.translationLocale(w, r, site.SiteID)
+	if !ok {
+		return
+	}
+	before, err := h.translation(r, pageID, locale)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	page := &models.Page{Content: input.Content, ContentFormat: input.ContentFormat}
+	if err := h.cleanContent(r.Context(), site.SiteID, page); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	translation := &models.PageTranslation{
+		PageID:        pageID,
+		Locale:        locale,
+		Title:         input.Title,
+		Slug:          input.Slug,
+		Content:       page.Content,
+		ContentFormat: input.ContentFormat,
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	err = h.pages.SaveTranslation(r.Context(), translation)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
+		return
+	}
+
+	saved, err := h.translation(r, pageID, locale)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if saved == nil {
+		response.Error(w, r, response.NotFound(response.CodeTranslationNotFound))
+		return
+	}
+	if !h.record(w, r, current.SiteID, models.EntityPageTranslation, pageID, saveAction(before != nil, true), before, saved) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, saved)
+}
+
+// translation 은 페이지의 locale 번역을 반환합니다. 번역이 없으면 nil 입니다.
+func (h *Handler) translation(r *http.Request, pageID int, locale string) (*models.PageTranslation, error) {
+	translations, err := h.pages.ListTranslations(r.Context(), pageID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range translations {
+		if translations[i].Locale == locale {
+			return &translations[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteTranslation godoc
+// @Summary 페이지 번역 삭제
+// @Description 페이지의 locale 번역을 공개 스냅샷과 함께 삭제합니다. 이후 그 언어는 fallback 언어로 응답합니다.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Param locale path string true "언어 태그"
+// @Success 200 {object} response.Response{data=map[string]bool}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/translations/{locale} [delete]
+func (h *Handler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+	locale, ok := i18n.Canonical(chi.URLParam(r, "locale"))
+	if !ok {
+		response.Error(w, r, response.InvalidParameter("locale"))
+		return
+	}
+
+	page, ok := h.pageFor(w, r, pageID, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+	before, err := h.translation(r, pageID, locale)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	err = h.pages.DeleteTranslation(r.Context(), pageID, locale)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeTranslationNotFound))
+		return
+	}
+	if !h.record(w, r, page.SiteID, models.EntityPageTranslation, pageID, models.AuditDelete, before, nil) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]bool{"deleted": true})
+}
+
+// GetTranslationReport godoc
+// @Summary 번역 누락 보고서
+// @Description 기본 언어가 아닌 언어별로 번역이 없는 페이지(missing)와, 공개 중이지만 번역이 아직 공개되지 않은 페이지(unpublished)를 조회합니다. 두 경우 모두 fallback 언어로 응답하고 있습니다. 볼 권한이 있는 그룹의 페이지만 포함합니다.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param locale query string false "확인할 언어 (생략하면 모든 언어)"
+// @Param group_id query int false "Group ID"
+// @Success 200 {object} response.Response{data=models.TranslationReport}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/translations/missing [get]
+func (h *Handler) GetTranslationReport(w http.ResponseWriter, r *http.Request) {
+	site, ok := h.siteFor(w, r, rbac.ActionAccess)
+	if !ok {
+		return
+	}
+	settings, err := i18n.Load(r.Context(), h.sites, site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	locale := ""
+	if value := r.URL.Query().Get("locale"); value != "" {
+		if locale, err = i18n.Negotiate(settings, value, ""); err != nil {
+			response.Error(w, r, response.NewError(http.StatusBadRequest, response.CodeUnsupportedLocale, value))
+			return
+		}
+	}
+	groupID := 0
+	if value := r.URL.Query().Get("group_id"); value != "" {
+		if groupID, err = strconv.Atoi(value); err != nil {
+			response.Error(w, r, response.InvalidParameter("group_id"))
+			return
+		}
+	}
+
+	pages, err := h.pages.ListSitePages(r.Context(), site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	perms := rbac.FromContext(r.Context())
+	filtered := []*models.Page{}
+	for _, page := range pages {
+		if (groupID == 0 || page.GroupID == groupID) && perms.Can(rbac.ActionView, site.SiteID, page.GroupID) {
+			filtered = append(filtered, page)
+		}
+	}
+	pages = filtered
+	translations, err := h.pages.ListSiteTranslations(r.Context(), site.SiteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, i18n.Report(settings, pages, translations, locale))
+}
+
+// translationLocale 은 경로의 locale 이 번역을 저장할 수 있는 언어(기본 언어가 아닌 지원 언어)인지 확인합니다.
+func (h *Handler) translationLocale(w http.ResponseWriter, r *http.Request, siteID int) (string, bool) {
+	settings, err := i18n.Load(r.Context(), h.sites, siteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return "", false
+	}
+	locale, ok := i18n.Canonical(chi.URLParam(r, "locale"))
+	if !ok {
+		response.Error(w, r, response.InvalidParameter("locale"))
+		return "", false
+	}
+	if locale == settings.DefaultLocale {
+		response.Error(w, r, response.Invalid(validate.Failed("locale", "default_locale", "")))
+		return "", false
+	}
+	for _, supported := range settings.Locales {
+		if supported == locale {
+			return locale, true
+		}
+	}
+	response.Error(w, r, response.NewError(http.StatusBadRequest, response.CodeUnsupportedLocale, locale))
+	return "", false
+}
+
+// localizer 는 ?locale= 또는 Accept-Language 로 요청 언어를 정하고 번역을 읽어 Localizer 를 만듭니다.
+// pageID 가 0 이면 사이트의 모든 번역을, 아니면 그 페이지의 번역만 읽습니다.
+func (h *Handler) localizer(w http.ResponseWriter, r *http.Request, siteID, pageID int) (*i18n.Localizer, bool) {
+	settings, err := i18n.Load(r.Context(), h.sites, siteID)
+	if err != nil {
+		response.Error(w, r, err)
+		return nil, false
+	}
+	requested := r.URL.Query().Get("locale")
+	locale, err := i18n.Negotiate(settings, requested, r.Header.Get("Accept-Language"))
+	if err != nil {
+		response.Error(w, r, response.NewError(http.StatusBadRequest, response.CodeUnsupportedLocale, requested))
+		return nil, false
+	}
+
+	var translations []models.PageTranslation
+	if locale != settings.DefaultLocale {
+		if pageID != 0 {
+			translations, err = h.pages.ListTranslations(r.Context(), pageID)
+		} else {
+			translations, err = h.pages.ListSiteTranslations(r.Context(), siteID)
+		}
+		if err != nil {
+			response.Error(w, r, err)
+			return nil, false
+		}
+	}
+
+	w.Header().Set("Content-Language", locale)
+	w.Header().Add("Vary", "Accept-Language")
+	return i18n.NewLocalizer(settings, locale, translations), true
+}
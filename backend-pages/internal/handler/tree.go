@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/pagetree"
+	"pages/internal/rbac"
+	"pages/pkg/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MovePage godoc
+// @Summary 페이지 이동
+// @Description 페이지를 새 부모 아래 지정한 형제의 앞(before_id)이나 뒤(after_id)로 옮깁니다. 하위 페이지의 depth 와 형제의 menu_order 는 서버에서 다시 계산합니다.
+// @Tags pages
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param page_id path int true "Page ID"
+// @Param input body models.MovePageInput true "이동할 위치"
+// @Success 200 {object} response.Response{data=models.Page}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/pages/{page_id}/move [post]
+func (h *Handler) MovePage(w http.ResponseWriter, r *http.Request) {
+	pageID, err := strconv.Atoi(chi.URLParam(r, "pageID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("page_id"))
+		return
+	}
+
+	var input models.MovePageInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	before, ok := h.pageFor(w, r, pageID, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.pages.MovePage(r.Context(), pageID, input); err != nil {
+		response.Error(w, r, storeError(err, response.CodePageNotFound))
+		return
+	}
+
+	page, err := h.pages.GetPage(r.Context(), pageID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, page.SiteID, models.EntityPage, page.PageID, models.AuditMove, before, page) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, page)
+}
+
+// SaveTree godoc
+// @Summary 메뉴 트리 일괄 저장
+// @Description 그룹의 메뉴 트리 전체를 한 번에 저장합니다. 메뉴 조회 결과와 같은 중첩 구조(page_id, menu)를 받아 모든 페이지의 부모, depth, menu_order 를 한 트랜잭션에서 갱신합니다. 그룹의 모든 페이지가 정확히 한 번씩 포함되어야 합니다.
+// @Tags pages
+// @Accept json
+// @Produce json
+// @Param site_code path string true "Site Code"
+// @Param group_id path int true "Group ID"
+// @Param tree body []models.TreeNodeInput true "메뉴 트리"
+// @Success 200 {object} response.Response{data=[]models.Page}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/sites/{site_code}/groups/{group_id}/tree [put]
+func (h *Handler) SaveTree(w http.ResponseWriter, r *http.Request) {
+	groupId, err := strconv.Atoi(chi.URLParam(r, "groupId"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("group_id"))
+		return
+	}
+
+	group, ok := h.groupFor(w, r, groupId, rbac.ActionEdit)
+	if !ok {
+		return
+	}
+
+	var tree []models.TreeNodeInput
+	if err := decodeJSON(w, r, &tree, false); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	before, err := h.pages.ListPages(r.Context(), group.SiteID, groupId)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.pages.SaveTree(r.Context(), groupId, tree); err != nil {
+		response.Error(w, r, storeError(err, response.CodeGroupNotFound))
+		return
+	}
+
+	pages, err := h.pages.ListPages(r.Context(), group.SiteID, groupId)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	// 트리 저장은 그룹의 메뉴 구조를 바꾸므로 그룹의 이동으로, 전후 트리를 스냅샷으로 남깁니다.
+	menu := pagetree.BuildMenuTree(pages)
+	if !h.record(w, r, group.SiteID, models.EntityPageGroup, group.GroupID, models.AuditMove, pagetree.BuildMenuTree(before), menu) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, menu)
+}
@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"net/http"
+	"pages/internal/auth"
+	"pages/internal/models"
+	"pages/internal/rbac"
+	"pages/internal/validate"
+	"pages/pkg/response"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetMyPermissions godoc
+// @Summary 내 권한 조회
+// @Description 요청한 사용자의 역할과 사이트, 그룹별로 할 수 있는 동작(view, edit, publish, manage, admin)을 조회합니다. 관리 화면은 actions 에 없는 동작의 컨트롤을 숨깁니다. super_admin 이면 모든 사이트의 모든 동작을 할 수 있습니다.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{data=models.Permissions}
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/me/permissions [get]
+func (h *Handler) GetMyPermissions(w http.ResponseWriter, r *http.Request) {
+	perms := rbac.FromContext(r.Context())
+	response.JSON(w, http.StatusOK, perms.Describe(auth.FromContext(r.Context())))
+}
+
+// ListUsers godoc
+// @Summary 사용자 목록 조회
+// @Description 역할을 줄 수 있는 사용자 목록을 조회합니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{data=[]models.User}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/users [get]
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	users, err := h.access.ListUsers(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, users)
+}
+
+// GetUser godoc
+// @Summary 사용자 조회
+// @Description 사용자와 그 역할을 조회합니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} response.Response{data=models.User}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/users/{user_id} [get]
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("user_id"))
+		return
+	}
+
+	user, err := h.access.GetUser(r.Context(), userID)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeUserNotFound))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, user)
+}
+
+// CreateUser godoc
+// @Summary 사용자 등록
+// @Description 역할을 줄 사용자를 등록합니다. subject 는 JWT 의 sub 클레임이나 API 키의 "apikey:<key_id>" 입니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param input body models.CreateUserInput true "인증 주체와 이름"
+// @Success 201 {object} response.Response{data=models.User}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/users [post]
+func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	var input models.CreateUserInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	id, err := h.access.CreateUser(r.Context(), input)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeUserNotFound))
+		return
+	}
+
+	user, err := h.access.GetUser(r.Context(), int(id))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, 0, models.EntityUser, user.UserID, models.AuditCreate, nil, user) {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, user)
+}
+
+// DeleteUser godoc
+// @Summary 사용자 삭제
+// @Description 사용자와 그 역할을 삭제합니다. 인증 수단(API 키, JWT)은 그대로이므로 역할 없이 인증만 됩니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} response.Response{data=map[string]bool}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/users/{user_id} [delete]
+func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("user_id"))
+		return
+	}
+
+	user, err := h.access.GetUser(r.Context(), userID)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeUserNotFound))
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.access.DeleteUser(r.Context(), userID); err != nil {
+		response.Error(w, r, storeError(err, response.CodeUserNotFound))
+		return
+	}
+	if !h.record(w, r, 0, models.EntityUser, user.UserID, models.AuditDelete, user, nil) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]bool{"deleted": true})
+}
+
+// CreateRoleGrant godoc
+// @Summary 역할 부여
+// @Description 사용자에게 역할을 줍니다. super-admin 은 site_code 없이, site-admin 은 site_code 로 사이트에, viewer, editor, publisher 는 site_code 로 사이트 전체에 주거나 group_id 로 그 사이트의 그룹 하나에 줍니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param input body models.CreateRoleGrantInput true "역할과 범위"
+// @Success 201 {object} response.Response{data=models.User}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/users/{user_id}/roles [post]
+func (h *Handler) CreateRoleGrant(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("user_id"))
+		return
+	}
+
+	var input models.CreateRoleGrantInput
+	if err := decode(w, r, &input); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	var siteID, groupID int
+	if input.SiteCode != "" {
+		site, err := h.sites.GetSiteByCode(r.Context(), input.SiteCode)
+		if err != nil {
+			response.Error(w, r, storeError(err, response.CodeSiteNotFound))
+			return
+		}
+		siteID = site.SiteID
+	}
+	if input.GroupID != nil {
+		group, err := h.groups.GetPageGroup(r.Context(), *input.GroupID)
+		if err != nil {
+			response.Error(w, r, storeError(err, response.CodeGroupNotFound))
+			return
+		}
+		if group.SiteID != siteID {
+			response.Error(w, r, response.Invalid(validate.Failed("group_id", "site", "site_code")))
+			return
+		}
+		groupID = group.GroupID
+	}
+	if !rbac.ValidScope(input.Role, siteID, groupID) {
+		response.Error(w, r, response.Invalid(validate.Failed("role", "scope", "")))
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	grantID, err := h.access.CreateGrant(r.Context(), userID, input.Role, optionalID(siteID), optionalID(groupID))
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeUserNotFound))
+		return
+	}
+
+	user, err := h.access.GetUser(r.Context(), userID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !h.record(w, r, siteID, models.EntityRoleGrant, int(grantID), models.AuditCreate, nil, findGrant(user, int(grantID))) {
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, user)
+}
+
+// optionalID 는 0 이면 nil(NULL)을 반환합니다.
+func optionalID(id int) *int {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// findGrant 는 사용자의 역할 중 grantID 를 반환합니다. 없으면 nil 입니다.
+func findGrant(user *models.User, grantID int) *models.RoleGrant {
+	for i := range user.Grants {
+		if user.Grants[i].GrantID == grantID {
+			return &user.Grants[i]
+		}
+	}
+	return nil
+}
+
+// DeleteRoleGrant godoc
+// @Summary 역할 회수
+// @Description 사용자에게 준 역할 하나를 회수합니다. super-admin 권한이 필요합니다.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param grant_id path int true "Grant ID"
+// @Success 200 {object} response.Response{data=map[string]bool}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Security BearerAuth
+// @Router /api/admin/users/{user_id}/roles/{grant_id} [delete]
+func (h *Handler) DeleteRoleGrant(w http.ResponseWriter, r *http.Request) {
+	if !authorize(w, r, rbac.ActionAdmin, 0, 0) {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("user_id"))
+		return
+	}
+	grantID, err := strconv.Atoi(chi.URLParam(r, "grantID"))
+	if err != nil {
+		response.Error(w, r, response.InvalidParameter("grant_id"))
+		return
+	}
+
+	user, err := h.access.GetUser(r.Context(), userID)
+	if err != nil {
+		response.Error(w, r, storeError(err, response.CodeUserNotFound))
+		return
+	}
+	grant := findGrant(user, grantID)
+	if grant == nil {
+		response.Error(w, r, response.NotFound(response.CodeRoleGrantNotFound))
+		return
+	}
+
+	r, rollback, ok := h.begin(w, r)
+	if !ok {
+		return
+	}
+	defer rollback()
+
+	if err := h.access.DeleteGrant(r.Context(), userID, grantID); err != nil {
+		response.Error(w, r, storeError(err, response.CodeRoleGrantNotFound))
+		return
+	}
+	siteID := 0
+	if grant.SiteID != nil {
+		siteID = *grant.SiteID
+	}
+	if !h.record(w, r, siteID, models.EntityRoleGrant, grant.GrantID, models.AuditDelete, grant, nil) {
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]bool{"deleted": true})
+}
@@ -0,0 +1,317 @@
+// Package i18n 은 사이트 언어 설정, 요청 언어 결정, 페이지 번역 적용을 담당합니다.
+//
+// 페이지 원문(pages 의 title, slug, content)은 사이트 기본 언어의 내용이고, 다른 언어는
+// page_translations 에 저장합니다. 요청 언어에 번역이 없으면 사이트에 설정된 fallback 언어를
+// 차례로 시도하고, 마지막에는 원문을 씁니다.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pages/internal/models"
+	"pages/internal/store"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported 는 요청한 언어를 사이트가 지원하지 않을 때 반환됩니다.
+var ErrUnsupported = errors.New("i18n: unsupported locale")
+
+// DefaultLocales 는 사이트 언어 설정이 없을 때 쓰는 기본값입니다. 원문은 한국어이고 영어 번역을 둘 수 있습니다.
+func DefaultLocales() models.SiteLocales {
+	return models.SiteLocales{
+		DefaultLocale: "ko",
+		Locales:       []string{"ko", "en"},
+		Fallbacks:     map[string][]string{},
+	}
+}
+
+// LocaleStore 는 Load 가 사용하는 저장소입니다. store.SiteStore 가 구현합니다.
+type LocaleStore interface {
+	GetSiteLocales(ctx context.Context, siteID int) (*models.SiteLocales, error)
+}
+
+// Load 는 사이트 언어 설정을 읽습니다. 설정이 없으면 DefaultLocales 를 반환합니다.
+func Load(ctx context.Context, locales LocaleStore, siteID int) (*models.SiteLocales, error) {
+	settings, err := locales.GetSiteLocales(ctx, siteID)
+	if errors.Is(err, store.ErrNotFound) {
+		defaults := DefaultLocales()
+		return &defaults, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Canonical 은 언어 태그를 en, en-US, zh-Hant 와 같은 형태로 맞춥니다.
+// 언어(2~3자)와 8자 이하의 영숫자 하위 태그로 이루어지지 않으면 false 를 반환합니다.
+func Canonical(tag string) (string, bool) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for i, part := range parts {
+		if part == "" || len(part) > 8 || !alnum(part) {
+			return "", false
+		}
+		switch {
+		case i == 0:
+			if len(part) < 2 || len(part) > 3 {
+				return "", false
+			}
+			parts[i] = strings.ToLower(part)
+		case len(part) == 2:
+			parts[i] = strings.ToUpper(part) // 지역
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:]) // 문자 체계
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-"), true
+}
+
+func alnum(s string) bool {
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateLocales 는 언어 설정을 검사하고 언어 태그를 Canonical 형태로 바꿉니다.
+// 기본 언어는 Locales 에 포함되어야 하며(없으면 추가합니다), fallback 언어도 모두 Locales 에 있어야 합니다.
+func ValidateLocales(settings *models.SiteLocales) error {
+	def, ok := Canonical(settings.DefaultLocale)
+	if !ok {
+		return fmt.Errorf("invalid default_locale %q", settings.DefaultLocale)
+	}
+	settings.DefaultLocale = def
+
+	seen := map[string]bool{def: true}
+	locales := []string{def}
+	for _, tag := range settings.Locales {
+		locale, ok := Canonical(tag)
+		if !ok {
+			return fmt.Errorf("invalid locale %q", tag)
+		}
+		if !seen[locale] {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	settings.Locales = locales
+
+	fallbacks := make(map[string][]string, len(settings.Fallbacks))
+	for tag, chain := range settings.Fallbacks {
+		locale, ok := Canonical(tag)
+		if !ok || !seen[locale] {
+			return fmt.Errorf("fallbacks: %q is not one of locales", tag)
+		}
+		for _, fallbackTag := range chain {
+			fallback, ok := Canonical(fallbackTag)
+			if !ok || !seen[fallback] {
+				return fmt.Errorf("fallbacks: %q is not one of locales", fallbackTag)
+			}
+			if fallback == locale {
+				return fmt.Errorf("fallbacks: %q cannot fall back to itself", tag)
+			}
+			fallbacks[locale] = append(fallbacks[locale], fallback)
+		}
+	}
+	settings.Fallbacks = fallbacks
+	return nil
+}
+
+// Negotiate 는 요청 언어를 정합니다. requested(?locale=)가 있으면 그 언어를, 없으면 Accept-Language 에서
+// 가중치가 높은 순으로 지원하는 언어를 찾고, 찾지 못하면 기본 언어를 씁니다.
+// requested 를 지원하지 않으면 ErrUnsupported 를 반환합니다.
+func Negotiate(settings *models.SiteLocales, requested, acceptLanguage string) (string, error) {
+	if requested != "" {
+		if locale := match(settings, requested); locale != "" {
+			return locale, nil
+		}
+		return "", fmt.Errorf("%w: %s", ErrUnsupported, requested)
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			break
+		}
+		if locale := match(settings, tag); locale != "" {
+			return locale, nil
+		}
+	}
+	return settings.DefaultLocale, nil
+}
+
+// match 는 tag 와 같은 언어, tag 의 상위 태그(en-US → en), 같은 주 언어를 쓰는 언어(en → en-GB) 순으로
+// 지원하는 언어를 찾습니다.
+func match(settings *models.SiteLocales, tag string) string {
+	locale, ok := Canonical(tag)
+	if !ok {
+		return ""
+	}
+	for candidate := locale; ; {
+		for _, supported := range settings.Locales {
+			if supported == candidate {
+				return supported
+			}
+		}
+		i := strings.LastIndex(candidate, "-")
+		if i < 0 {
+			break
+		}
+		candidate = candidate[:i]
+	}
+	language, _, _ := strings.Cut(locale, "-")
+	for _, supported := range settings.Locales {
+		if strings.HasPrefix(supported, language+"-") {
+			return supported
+		}
+	}
+	return ""
+}
+
+// parseAcceptLanguage 는 Accept-Language 헤더의 언어 태그를 q 값이 높은 순으로 반환합니다. q=0 인 태그는 뺍니다.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// Chain 은 locale 의 번역을 찾을 순서입니다. locale, 설정된 fallback 언어, 기본 언어 순이며 중복은 뺍니다.
+func Chain(settings *models.SiteLocales, locale string) []string {
+	chain := []string{}
+	seen := map[string]bool{}
+	for _, l := range append(append([]string{locale}, settings.Fallbacks[locale]...), settings.DefaultLocale) {
+		if !seen[l] {
+			seen[l] = true
+			chain = append(chain, l)
+		}
+	}
+	return chain
+}
+
+// Localizer 는 페이지에 요청 언어의 번역을 적용합니다.
+type Localizer struct {
+	Locale        string
+	Chain         []string
+	defaultLocale string
+	translations  map[int]map[string]*models.PageTranslation
+}
+
+// NewLocalizer 는 locale 과 사이트 번역 목록으로 Localizer 를 만듭니다.
+func NewLocalizer(settings *models.SiteLocales, locale string, translations []models.PageTranslation) *Localizer {
+	l := &Localizer{
+		Locale:        locale,
+		Chain:         Chain(settings, locale),
+		defaultLocale: settings.DefaultLocale,
+		translations:  make(map[int]map[string]*models.PageTranslation),
+	}
+	for i := range translations {
+		t := &translations[i]
+		if l.translations[t.PageID] == nil {
+			l.translations[t.PageID] = make(map[string]*models.PageTranslation)
+		}
+		l.translations[t.PageID][t.Locale] = t
+	}
+	return l
+}
+
+// Page 는 Chain 순서로 처음 찾은 번역을 적용한 사본을 반환합니다. published 이면 번역의 공개 스냅샷만
+// 사용하므로, page 는 PublishedView 로 얻은 공개 스냅샷이어야 합니다. 번역이 없으면 원문에 기본 언어를 표시합니다.
+func (l *Localizer) Page(page *models.Page, published bool) *models.Page {
+	view := *page
+	view.Locale = l.defaultLocale
+	for _, locale := range l.Chain {
+		if locale == l.defaultLocale {
+			break
+		}
+		t := l.translations[page.PageID][locale]
+		if t == nil {
+			continue
+		}
+		switch {
+		case !published:
+			view.Title, view.Slug, view.Content, view.ContentFormat = t.Title, t.Slug, t.Content, t.ContentFormat
+		case t.Published != nil:
+			p := t.Published
+			view.Title, view.Slug, view.Content, view.ContentFormat = p.Title, p.Slug, p.Content, p.ContentFormat
+		default:
+			continue
+		}
+		view.Locale = locale
+		view.Revision = 0
+		break
+	}
+	return &view
+}
+
+// Pages 는 pages 각각에 Page 를 적용한 목록을 반환합니다.
+func (l *Localizer) Pages(pages []*models.Page, published bool) []*models.Page {
+	result := make([]*models.Page, len(pages))
+	for i, page := range pages {
+		result[i] = l.Page(page, published)
+	}
+	return result
+}
+
+// Report 는 기본 언어가 아닌 언어별로 번역이 없거나 공개되지 않은 페이지를 모읍니다.
+// locale 이 비어 있지 않으면 그 언어만 확인합니다.
+func Report(settings *models.SiteLocales, pages []*models.Page, translations []models.PageTranslation, locale string) models.TranslationReport {
+	l := NewLocalizer(settings, settings.DefaultLocale, translations)
+	report := models.TranslationReport{DefaultLocale: settings.DefaultLocale, Locales: []models.LocaleTranslations{}}
+	for _, loc := range settings.Locales {
+		if loc == settings.DefaultLocale || (locale != "" && loc != locale) {
+			continue
+		}
+		entry := models.LocaleTranslations{Locale: loc, Total: len(pages), Missing: []models.MissingTranslation{}}
+		for _, page := range pages {
+			reason := ""
+			t := l.translations[page.PageID][loc]
+			switch {
+			case t == nil:
+				reason = models.TranslationMissing
+			case page.IsPublished && t.Published == nil:
+				reason = models.TranslationUnpublished
+			default:
+				continue
+			}
+			entry.Missing = append(entry.Missing, models.MissingTranslation{
+				PageID:  page.PageID,
+				GroupID: page.GroupID,
+				Title:   page.Title,
+				Slug:    page.Slug,
+				Reason:  reason,
+			})
+		}
+		report.Locales = append(report.Locales, entry)
+	}
+	return report
+}
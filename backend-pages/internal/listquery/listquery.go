@@ -0,0 +1,329 @@
+// Package listquery 는 목록 API 의 limit/cursor 페이지네이션, 필터, 정렬 쿼리 파라미터를 해석하고
+// MySQL 과 SQLite 에서 모두 동작하는 WHERE, ORDER BY, LIMIT 절로 바꿉니다.
+//
+// 커서는 이전 목록의 마지막 행 키(예: page_id)입니다. 다음 목록은 정렬 값을 커서에 담지 않고
+// 그 행(앵커)의 현재 값과 비교해 이어 가므로, 정렬 기준이 시각이나 문자열이어도 드라이버와 상관없이
+// 같은 결과가 나옵니다. 앵커 행이 지워진 커서는 ErrCursor 로 거부합니다.
+package listquery
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLimit 은 limit 파라미터가 없을 때 한 번에 반환하는 행 수입니다.
+	DefaultLimit = 50
+	// MaxLimit 은 limit 의 최댓값입니다. 더 크게 요청하면 MaxLimit 으로 줄입니다.
+	MaxLimit = 200
+)
+
+// ErrCursor 는 커서가 가리키는 행이 더 이상 없을 때 반환됩니다.
+var ErrCursor = errors.New("listquery: cursor row not found")
+
+// ParamError 는 쿼리 파라미터 Param 의 값이 올바르지 않을 때 반환됩니다.
+type ParamError struct {
+	Param string
+}
+
+func (e *ParamError) Error() string {
+	return "listquery: invalid parameter " + e.Param
+}
+
+// Kind 는 필터 값의 형식입니다.
+type Kind int
+
+const (
+	// Bool 은 true/false 값과 같은지 비교합니다.
+	Bool Kind = iota
+	// Int 는 정수 값과 같은지 비교합니다.
+	Int
+	// NullableInt 는 Int 와 같지만 "null" 이면 IS NULL 로 비교합니다(예: parent_id=null 은 최상위 페이지).
+	NullableInt
+	// Since 는 RFC 3339 시각이나 날짜(2006-01-02) 이후인지 비교합니다.
+	Since
+	// Until 은 Since 와 같은 형식의 시각보다 이전인지 비교합니다. 시각은 포함하지 않습니다.
+	Until
+	// String 은 문자열과 같은지 비교합니다.
+	String
+	// Contains 는 문자열을 포함하는지 LIKE 로 비교합니다. 대소문자 구분은 DB 정렬 규칙을 따릅니다.
+	Contains
+)
+
+// Field 는 필터나 정렬에 쓸 수 있는 값입니다. Expr 은 fmt 형식의 SQL 식으로 %[1]s 에 테이블 별칭이
+// 들어갑니다. 비어 있으면 "<별칭>.<Name>" 컬럼입니다. 정렬 필드의 식은 NULL 이 아니어야 합니다.
+type Field struct {
+	Name string
+	Expr string
+	Kind Kind
+}
+
+func (f Field) expr(alias string) string {
+	if f.Expr == "" {
+		return alias + "." + f.Name
+	}
+	return fmt.Sprintf(f.Expr, alias)
+}
+
+// Spec 은 목록 하나에 허용하는 정렬, 필터와 커서 키를 정의합니다.
+type Spec struct {
+	Table string // 커서 앵커를 조회할 테이블
+	Alias string // 목록 쿼리에서 Table 의 별칭
+	Key   string // 커서로 쓰는 고유 정수 키 컬럼. 모든 정렬의 마지막 기준입니다.
+
+	Sorts       []Field
+	DefaultSort string // sort 파라미터가 없을 때의 정렬 (예: "depth,menu_order")
+	Filters     []Field
+}
+
+func find(fields []Field, name string) (Field, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+type order struct {
+	field Field
+	desc  bool
+}
+
+type filter struct {
+	field Field
+	value interface{} // nil 이면 IS NULL
+}
+
+// Query 는 해석한 목록 요청입니다.
+type Query struct {
+	spec    Spec
+	Limit   int
+	After   int64 // 커서 앵커 키. 0 이면 첫 목록입니다.
+	orders  []order
+	filters []filter
+	allowed []allowed
+}
+
+// allowed 는 Restrict 로 제한한 컬럼과 허용하는 값입니다.
+type allowed struct {
+	column string
+	ids    []int
+}
+
+// Restrict 는 목록을 column 값이 ids 중 하나인 행으로 제한합니다. 권한으로 볼 수 있는 행만 남길 때 쓰며,
+// ids 가 비어 있으면 아무 행도 반환하지 않습니다. column 은 Spec.Alias 테이블의 컬럼입니다.
+func (q *Query) Restrict(column string, ids []int) {
+	q.allowed = append(q.allowed, allowed{column: column, ids: ids})
+}
+
+// Parse 는 limit, cursor, sort 와 spec 에 정의된 필터 파라미터를 해석합니다.
+// sort 는 쉼표로 구분한 필드 목록이며, 이름 앞에 - 를 붙이면 내림차순입니다(예: sort=-updated_at,title).
+// 잘못된 값은 *ParamError 로 반환합니다.
+func Parse(params url.Values, spec Spec) (*Query, error) {
+	q := &Query{spec: spec, Limit: DefaultLimit}
+
+	if value := params.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, &ParamError{Param: "limit"}
+		}
+		q.Limit = min(limit, MaxLimit)
+	}
+
+	if value := params.Get("cursor"); value != "" {
+		after, err := decodeCursor(value)
+		if err != nil {
+			return nil, &ParamError{Param: "cursor"}
+		}
+		q.After = after
+	}
+
+	sort := params.Get("sort")
+	if sort == "" {
+		sort = spec.DefaultSort
+	}
+	seen := map[string]bool{}
+	for _, name := range strings.Split(sort, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		field, ok := find(spec.Sorts, name)
+		if !ok || seen[name] {
+			return nil, &ParamError{Param: "sort"}
+		}
+		seen[name] = true
+		q.orders = append(q.orders, order{field: field, desc: desc})
+	}
+
+	for _, field := range spec.Filters {
+		value := params.Get(field.Name)
+		if value == "" {
+			continue
+		}
+		v, err := parseValue(field.Kind, value)
+		if err != nil {
+			return nil, &ParamError{Param: field.Name}
+		}
+		q.filters = append(q.filters, filter{field: field, value: v})
+	}
+
+	return q, nil
+}
+
+func parseValue(kind Kind, value string) (interface{}, error) {
+	switch kind {
+	case Bool:
+		return strconv.ParseBool(value)
+	case NullableInt:
+		if value == "null" {
+			return nil, nil
+		}
+		fallthrough
+	case Int:
+		return strconv.Atoi(value)
+	case Since, Until:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.DateOnly, value)
+	case Contains:
+		return "%" + likeEscaper.Replace(value) + "%", nil
+	case String:
+		return value, nil
+	}
+	return nil, fmt.Errorf("listquery: unknown kind %d", kind)
+}
+
+// likeEscaper 는 LIKE 패턴 문자를 이스케이프합니다. 백슬래시는 MySQL 문자열 리터럴에서도
+// 이스케이프 문자라 두 DB 에서 같게 쓸 수 있는 ! 를 ESCAPE 문자로 씁니다.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// SQL 은 base 쿼리(WHERE 절까지 작성된 SELECT)에 필터, 커서 조건과 ORDER BY, LIMIT 을 덧붙입니다.
+// 다음 목록이 있는지 알 수 있도록 Limit 보다 한 행 더 가져오며, 결과는 Page 로 자릅니다.
+// timeArg 는 Since, Until 필터의 시각을 DB 에 저장된 형식과 비교할 수 있는 인자로 바꿉니다.
+func (q *Query) SQL(base string, args []interface{}, timeArg func(time.Time) interface{}) (string, []interface{}) {
+	var b strings.Builder
+	b.WriteString(base)
+	alias := q.spec.Alias
+
+	for _, f := range q.filters {
+		expr := f.field.expr(alias)
+		switch {
+		case f.value == nil:
+			b.WriteString(" AND " + expr + " IS NULL")
+			continue
+		case f.field.Kind == Since:
+			b.WriteString(" AND " + expr + " >= ?")
+			args = append(args, timeArg(f.value.(time.Time)))
+		case f.field.Kind == Until:
+			b.WriteString(" AND " + expr + " < ?")
+			args = append(args, timeArg(f.value.(time.Time)))
+		case f.field.Kind == Contains:
+			b.WriteString(" AND " + expr + " LIKE ? ESCAPE '!'")
+			args = append(args, f.value)
+		default:
+			b.WriteString(" AND " + expr + " = ?")
+			args = append(args, f.value)
+		}
+	}
+
+	for _, a := range q.allowed {
+		if len(a.ids) == 0 {
+			b.WriteString(" AND 1 = 0")
+			continue
+		}
+		b.WriteString(" AND " + alias + "." + a.column + " IN (?" + strings.Repeat(", ?", len(a.ids)-1) + ")")
+		for _, id := range a.ids {
+			args = append(args, id)
+		}
+	}
+
+	if q.After != 0 {
+		cond, condArgs := q.after()
+		b.WriteString(" AND " + cond)
+		args = append(args, condArgs...)
+	}
+
+	b.WriteString(" ORDER BY ")
+	for _, o := range q.orders {
+		b.WriteString(o.field.expr(alias))
+		if o.desc {
+			b.WriteString(" DESC")
+		}
+		b.WriteString(", ")
+	}
+	b.WriteString(alias + "." + q.spec.Key)
+	b.WriteString(" LIMIT " + strconv.Itoa(q.Limit+1))
+
+	return b.String(), args
+}
+
+// after 는 정렬 순서에서 앵커 행 뒤에 오는 행의 조건을 만듭니다. 정렬 필드가 a, b 이면
+// a > A OR (a = A AND b > B) OR (a = A AND b = B AND key > K) 이고, A, B 는 앵커 행의 값입니다.
+func (q *Query) after() (string, []interface{}) {
+	const anchor = "c"
+	alias := q.spec.Alias
+	value := func(f Field) string {
+		return fmt.Sprintf("(SELECT %s FROM %s %s WHERE %s.%s = ?)", f.expr(anchor), q.spec.Table, anchor, anchor, q.spec.Key)
+	}
+
+	var terms []string
+	var args []interface{}
+	var equal []string
+	var equalArgs []interface{}
+	for _, o := range q.orders {
+		op := ">"
+		if o.desc {
+			op = "<"
+		}
+		term := append(append([]string{}, equal...), o.field.expr(alias)+" "+op+" "+value(o.field))
+		terms = append(terms, "("+strings.Join(term, " AND ")+")")
+		args = append(append(args, equalArgs...), q.After)
+
+		equal = append(equal, o.field.expr(alias)+" = "+value(o.field))
+		equalArgs = append(equalArgs, q.After)
+	}
+	term := append(equal, alias+"."+q.spec.Key+" > ?")
+	terms = append(terms, "("+strings.Join(term, " AND ")+")")
+	args = append(append(args, equalArgs...), q.After)
+
+	return "(" + strings.Join(terms, " OR ") + ")", args
+}
+
+// Page 는 SQL 로 가져온 n 행 중 반환할 행 수와, 다음 목록이 있으면 그 커서를 반환합니다.
+// key 는 i 번째 행의 키를 돌려줍니다.
+func (q *Query) Page(n int, key func(i int) int64) (int, string) {
+	if n <= q.Limit {
+		return n, ""
+	}
+	return q.Limit, encodeCursor(key(q.Limit - 1))
+}
+
+// AnchorSQL 은 커서 앵커 행이 있는지 확인하는 쿼리입니다. 인자는 After 하나입니다.
+func (q *Query) AnchorSQL() string {
+	return fmt.Sprintf("SELECT 1 FROM %s WHERE %s = ?", q.spec.Table, q.spec.Key)
+}
+
+func encodeCursor(key int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(key, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	key, err := strconv.ParseInt(string(b), 10, 64)
+	if err == nil && key < 1 {
+		err = errors.New("listquery: invalid cursor")
+	}
+	return key, err
+}
@@ -0,0 +1,81 @@
+// Package mergepatch 는 JSON Merge Patch(RFC 7396)를 구현합니다.
+//
+// patch 의 각 필드는 target 의 같은 필드를 바꾸며, 값이 null 이면 필드를 지웁니다.
+// 값이 객체이면 target 의 객체에 재귀적으로 적용하고, 그 밖의 값(배열 포함)은 통째로 바꿉니다.
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// ContentType 은 merge patch 문서의 미디어 타입입니다.
+const ContentType = "application/merge-patch+json"
+
+// ErrNotObject 는 patch 가 JSON 객체가 아닐 때 Fields 가 반환합니다.
+var ErrNotObject = errors.New("mergepatch: patch must be a JSON object")
+
+// Apply 는 target 문서에 patch 를 적용한 문서를 반환합니다. target 이 비어 있으면 null 로 봅니다.
+func Apply(target, patch []byte) ([]byte, error) {
+	var t interface{}
+	if len(bytes.TrimSpace(target)) > 0 {
+		if err := unmarshal(target, &t); err != nil {
+			return nil, err
+		}
+	}
+	var p interface{}
+	if err := unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(t, p))
+}
+
+// Fields 는 patch 가 바꾸거나 지우는 최상위 필드 이름을 반환합니다.
+func Fields(patch []byte) (map[string]bool, error) {
+	var p interface{}
+	if err := unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	obj, ok := p.(map[string]interface{})
+	if !ok {
+		return nil, ErrNotObject
+	}
+	fields := make(map[string]bool, len(obj))
+	for name := range obj {
+		fields[name] = true
+	}
+	return fields, nil
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for name, value := range patchObj {
+		if value == nil {
+			delete(targetObj, name)
+			continue
+		}
+		targetObj[name] = merge(targetObj[name], value)
+	}
+	return targetObj
+}
+
+// unmarshal 은 숫자를 float64 로 바꾸지 않도록 json.Number 로 읽습니다. 큰 정수 ID 가 바뀌지 않게 합니다.
+func unmarshal(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("mergepatch: unexpected data after JSON document")
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrations/<driver>/<version>_<name>.up.sql, .down.sql 형식의 파일을 바이너리에 포함합니다.
+//
+//go:embed migrations
+var migrationsFS embed.FS
+
+// ErrChecksumMismatch 는 이미 적용된 마이그레이션 파일이 수정되었을 때 반환됩니다.
+var ErrChecksumMismatch = errors.New("migrate: checksum mismatch")
+
+// mysqlLockName 은 여러 인스턴스가 동시에 마이그레이션하지 않도록 잡는 GET_LOCK 이름입니다.
+const mysqlLockName = "pages_schema_migrations"
+
+const createTableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // Up 스크립트의 SHA-256
+}
+
+// 마이그레이션 상태
+const (
+	StatePending  = "pending"  // 아직 적용되지 않음
+	StateApplied  = "applied"  // 적용됨
+	StateModified = "modified" // 적용 후 파일이 수정됨
+	StateMissing  = "missing"  // DB 에는 적용되어 있으나 바이너리에 파일이 없음
+)
+
+type Status struct {
+	Version   int64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	migrations []Migration
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// New 는 driver("mysql", "sqlite")에 맞는 내장 마이그레이션으로 Migrator 를 생성합니다.
+func New(db *sql.DB, driver string) (*Migrator, error) {
+	migrations, err := load(driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, driver: driver, migrations: migrations}, nil
+}
+
+func load(driver string) ([]Migration, error) {
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrationsFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: %s 드라이버용 마이그레이션이 없습니다: %w", driver, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		versionText, name, ok := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(versionText, 10, 64)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("migrate: 잘못된 마이그레이션 파일 이름입니다: %s", fileName)
+		}
+
+		body, err := fs.ReadFile(migrationsFS, path.Join(dir, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migrate: 버전 %d 의 이름이 다릅니다: %s, %s", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			sum := sha256.Sum256(body)
+			m.Up = string(body)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migrate: 버전 %d 의 up 스크립트가 없습니다", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up 은 적용되지 않은 마이그레이션을 버전 순서대로 모두 적용하고, 적용한 목록을 반환합니다.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(done); err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+			err := m.exec(ctx, conn, mig.Up,
+				"INSERT INTO schema_migrations (version, name, checksum) VALUES (?, ?, ?)",
+				mig.Version, mig.Name, mig.Checksum,
+			)
+			if err != nil {
+				return fmt.Errorf("migrate: %04d_%s 적용 실패: %w", mig.Version, mig.Name, err)
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down 은 가장 최근에 적용된 마이그레이션부터 steps 개를 되돌리고, 되돌린 목록을 반환합니다.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(done); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := done[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migrate: %04d_%s 는 down 스크립트가 없어 되돌릴 수 없습니다", mig.Version, mig.Name)
+			}
+			err := m.exec(ctx, conn, mig.Down,
+				"DELETE FROM schema_migrations WHERE version = ?",
+				mig.Version,
+			)
+			if err != nil {
+				return fmt.Errorf("migrate: %04d_%s 되돌리기 실패: %w", mig.Version, mig.Name, err)
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status 는 내장 마이그레이션과 DB 에 기록된 마이그레이션의 상태를 버전 순으로 반환합니다.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withConn(ctx, func(conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[int64]bool)
+		for _, mig := range m.migrations {
+			known[mig.Version] = true
+			status := Status{Version: mig.Version, Name: mig.Name, State: StatePending}
+			if row, ok := done[mig.Version]; ok {
+				appliedAt := row.appliedAt
+				status.AppliedAt = &appliedAt
+				status.State = StateApplied
+				if row.checksum != mig.Checksum {
+					status.State = StateModified
+				}
+			}
+			statuses = append(statuses, status)
+		}
+		for version, row := range done {
+			if known[version] {
+				continue
+			}
+			appliedAt := row.appliedAt
+			statuses = append(statuses, Status{Version: version, Name: row.name, State: StateMissing, AppliedAt: &appliedAt})
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// withConn 은 하나의 연결 위에서 schema_migrations 테이블을 준비한 뒤 fn 을 실행합니다.
+// MySQL 에서는 GET_LOCK 으로 다른 인스턴스와의 동시 실행을 막습니다.
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.driver == "mysql" {
+		var locked sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 60)", mysqlLockName).Scan(&locked); err != nil {
+			return err
+		}
+		if locked.Int64 != 1 {
+			return errors.New("migrate: 다른 인스턴스가 마이그레이션 중입니다")
+		}
+		defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", mysqlLockName)
+	}
+
+	if _, err := conn.ExecContext(ctx, createTableSQL); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var version int64
+		var row appliedMigration
+		if err := rows.Scan(&version, &row.name, &row.checksum, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = row
+	}
+	return done, rows.Err()
+}
+
+// verify 는 적용된 마이그레이션 파일이 그 뒤로 수정되지 않았는지 확인합니다.
+func (m *Migrator) verify(done map[int64]appliedMigration) error {
+	for _, mig := range m.migrations {
+		if row, ok := done[mig.Version]; ok && row.checksum != mig.Checksum {
+			return fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, mig.Version, mig.Name)
+		}
+	}
+	return nil
+}
+
+// exec 는 스크립트와 schema_migrations 기록을 하나의 트랜잭션에서 실행합니다.
+// MySQL 의 DDL 은 암묵적으로 커밋되므로 SQLite 에서만 완전히 원자적입니다.
+func (m *Migrator) exec(ctx context.Context, conn *sql.Conn, script, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
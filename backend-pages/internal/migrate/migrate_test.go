@@ -0,0 +1,109 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 메모리 DB 는 연결마다 따로 생기므로 연결을 하나로 묶습니다.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestUpDown(t *testing.T) {
+	ctx := context.Background()
+	m, err := New(openSQLite(t), "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(m.migrations) {
+		t.Fatalf("Up applied %d, want %d", len(applied), len(m.migrations))
+	}
+	// 두 번째 Up 은 적용할 것이 없습니다.
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("second Up = %d, %v; want 0, nil", len(applied), err)
+	}
+
+	reverted, err := m.Down(ctx, 2)
+	if err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	last := m.migrations[len(m.migrations)-1]
+	if len(reverted) != 2 || reverted[0].Version != last.Version {
+		t.Fatalf("Down reverted %+v, want the last two starting at %d", reverted, last.Version)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range statuses {
+		want := StateApplied
+		if i >= len(statuses)-2 {
+			want = StatePending
+		}
+		if s.State != want {
+			t.Errorf("%04d_%s state = %s, want %s", s.Version, s.Name, s.State, want)
+		}
+	}
+
+	// 되돌린 마이그레이션은 다시 적용할 수 있어야 합니다.
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 2 {
+		t.Fatalf("Up after Down = %d, %v; want 2, nil", len(applied), err)
+	}
+	if _, err := m.Down(ctx, len(m.migrations)); err != nil {
+		t.Fatalf("Down all: %v", err)
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m, err := New(db, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 적용 후 파일이 바뀐 것처럼 기록된 체크섬을 바꿉니다.
+	first := m.migrations[0]
+	if _, err := db.Exec("UPDATE schema_migrations SET checksum = ? WHERE version = ?", "modified", first.Version); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Up(ctx); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Up error = %v, want ErrChecksumMismatch", err)
+	}
+	if _, err := m.Down(ctx, 1); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Down error = %v, want ErrChecksumMismatch", err)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[0].State != StateModified {
+		t.Errorf("%04d_%s state = %s, want %s", first.Version, first.Name, statuses[0].State, StateModified)
+	}
+	if statuses[1].State != StateApplied {
+		t.Errorf("%04d_%s state = %s, want %s", statuses[1].Version, statuses[1].Name, statuses[1].State, StateApplied)
+	}
+}
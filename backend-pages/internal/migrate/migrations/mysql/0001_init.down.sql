@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS pages;
+DROP TABLE IF EXISTS page_groups;
+DROP TABLE IF EXISTS sites;
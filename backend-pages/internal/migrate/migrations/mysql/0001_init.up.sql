@@ -0,0 +1,52 @@
+-- 사이트 정보 테이블
+CREATE TABLE IF NOT EXISTS sites (
+    site_id INT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,             -- 사이트 구분 코드 (예: 'cloud', 'store')
+    name VARCHAR(255) NOT NULL,            -- 사이트 이름
+    domain VARCHAR(255) NOT NULL DEFAULT '',                   -- 도메인 (옵션)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NULL,
+    UNIQUE KEY (code)
+);
+
+-- 페이지 그룹 테이블
+CREATE TABLE IF NOT EXISTS page_groups (
+    group_id INT AUTO_INCREMENT PRIMARY KEY,
+    site_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NULL,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE,
+    UNIQUE KEY (site_id, name)
+);
+
+-- 페이지와 메뉴 관리를 위한 테이블
+CREATE TABLE IF NOT EXISTS pages (
+    page_id INT AUTO_INCREMENT PRIMARY KEY,
+    site_id INT NOT NULL,
+    group_id INT NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    slug VARCHAR(255) NOT NULL DEFAULT '',
+    parent_id INT NULL DEFAULT NULL,
+    depth INT NOT NULL DEFAULT 0,
+    content TEXT NOT NULL DEFAULT '',
+    menu_order INT NOT NULL DEFAULT 0,
+    is_published BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NULL,
+    FOREIGN KEY (parent_id) REFERENCES pages(page_id) ON DELETE CASCADE,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES page_groups(group_id) ON DELETE CASCADE,
+    UNIQUE KEY (site_id, slug, parent_id),
+    INDEX idx_group_id (group_id)
+);
+
+CREATE TRIGGER IF NOT EXISTS before_pages_update
+BEFORE UPDATE ON pages
+FOR EACH ROW
+BEGIN
+    IF NEW.is_published != OLD.is_published THEN
+        SET NEW.updated_at = CURRENT_TIMESTAMP;
+    END IF;
+END;
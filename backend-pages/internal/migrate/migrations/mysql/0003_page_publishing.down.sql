@@ -0,0 +1,5 @@
+ALTER TABLE pages ALTER COLUMN is_published SET DEFAULT true;
+
+ALTER TABLE pages
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS published_revision;
@@ -0,0 +1,14 @@
+-- 공개 스냅샷: title, slug, content 는 작업 중인 초안이고,
+-- 공개된 내용은 published_revision 이 가리키는 page_revisions 행입니다.
+ALTER TABLE pages
+    ADD COLUMN IF NOT EXISTS published_revision INT NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP NULL DEFAULT NULL;
+
+-- 기존 공개 페이지는 최신 리비전을 공개 스냅샷으로 지정
+UPDATE pages p
+SET p.published_revision = (SELECT MAX(r.revision) FROM page_revisions r WHERE r.page_id = p.page_id),
+    p.published_at = COALESCE(p.updated_at, p.created_at)
+WHERE p.is_published = true;
+
+-- 새 페이지는 초안으로 생성
+ALTER TABLE pages ALTER COLUMN is_published SET DEFAULT false;
@@ -0,0 +1,5 @@
+ALTER TABLE pages
+    DROP INDEX IF EXISTS idx_unpublish_at,
+    DROP INDEX IF EXISTS idx_publish_at,
+    DROP COLUMN IF EXISTS unpublish_at,
+    DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,6 @@
+-- 예약 공개/만료 시각. 스케줄러가 처리하면 NULL 로 비웁니다.
+ALTER TABLE pages
+    ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP NULL DEFAULT NULL,
+    ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP NULL DEFAULT NULL,
+    ADD INDEX IF NOT EXISTS idx_publish_at (publish_at),
+    ADD INDEX IF NOT EXISTS idx_unpublish_at (unpublish_at);
@@ -0,0 +1,7 @@
+-- 사이트별 렌더링 테마 (html/template)
+CREATE TABLE IF NOT EXISTS site_themes (
+    site_id INT PRIMARY KEY,
+    template MEDIUMTEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE page_revisions DROP COLUMN IF EXISTS content_format;
+ALTER TABLE pages DROP COLUMN IF EXISTS content_format;
@@ -0,0 +1,6 @@
+-- 본문 형식 (markdown, html, plain). 기존 본문은 HTML 로 간주합니다.
+ALTER TABLE pages
+    ADD COLUMN IF NOT EXISTS content_format VARCHAR(16) NOT NULL DEFAULT 'html' AFTER content;
+
+ALTER TABLE page_revisions
+    ADD COLUMN IF NOT EXISTS content_format VARCHAR(16) NOT NULL DEFAULT 'html' AFTER content;
@@ -0,0 +1,7 @@
+ALTER TABLE page_revisions
+    DROP INDEX IF EXISTS ft_page_revisions_text,
+    DROP INDEX IF EXISTS ft_page_revisions_title;
+
+ALTER TABLE pages
+    DROP INDEX IF EXISTS ft_pages_text,
+    DROP INDEX IF EXISTS ft_pages_title;
@@ -0,0 +1,9 @@
+-- 페이지 검색용 FULLTEXT 인덱스. 초안은 pages, 공개 스냅샷은 page_revisions 에서 검색합니다.
+-- 제목은 가중치를 따로 주기 위해 단독 인덱스도 둡니다.
+ALTER TABLE pages
+    ADD FULLTEXT INDEX IF NOT EXISTS ft_pages_title (title),
+    ADD FULLTEXT INDEX IF NOT EXISTS ft_pages_text (title, content);
+
+ALTER TABLE page_revisions
+    ADD FULLTEXT INDEX IF NOT EXISTS ft_page_revisions_title (title),
+    ADD FULLTEXT INDEX IF NOT EXISTS ft_page_revisions_text (title, content);
@@ -0,0 +1,3 @@
+ALTER TABLE pages DROP COLUMN IF EXISTS version;
+ALTER TABLE page_groups DROP COLUMN IF EXISTS version;
+ALTER TABLE sites DROP COLUMN IF EXISTS version;
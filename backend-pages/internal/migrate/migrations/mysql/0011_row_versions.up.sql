@@ -0,0 +1,9 @@
+-- 낙관적 동시성 제어(ETag, If-Match)용 행 버전. 행을 바꿀 때마다 1씩 올립니다.
+ALTER TABLE sites
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE page_groups
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE pages
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,8 @@
+-- 바꾸기 전의 사이트 코드. 옛 코드로 온 요청은 현재 코드의 같은 경로로 리다이렉트합니다.
+-- 별칭은 다른 사이트가 쓸 수 없으며, 사이트를 지우면 함께 지워집니다.
+CREATE TABLE IF NOT EXISTS site_code_aliases (
+    code VARCHAR(50) PRIMARY KEY,
+    site_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE
+);
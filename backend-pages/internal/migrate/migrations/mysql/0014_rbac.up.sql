@@ -0,0 +1,22 @@
+-- RBAC 사용자. subject 는 인증 주체로 JWT 의 sub 나 API 키의 "apikey:<key_id>" 입니다.
+CREATE TABLE IF NOT EXISTS users (
+    user_id INT AUTO_INCREMENT PRIMARY KEY,
+    subject VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY (subject)
+);
+
+-- 사용자 역할. super-admin 은 site_id, group_id 없이 전체에, site-admin 은 사이트에,
+-- viewer, editor, publisher 는 사이트나 그룹(group_id 와 그 그룹의 site_id)에 줍니다.
+CREATE TABLE IF NOT EXISTS role_grants (
+    grant_id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    role VARCHAR(20) NOT NULL,             -- viewer, editor, publisher, site-admin, super-admin
+    site_id INT NULL DEFAULT NULL,
+    group_id INT NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES page_groups(group_id) ON DELETE CASCADE
+);
@@ -0,0 +1,51 @@
+-- 사이트 정보 테이블
+CREATE TABLE IF NOT EXISTS sites (
+    site_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code VARCHAR(50) NOT NULL UNIQUE,      -- 사이트 구분 코드 (예: 'cloud', 'store')
+    name VARCHAR(255) NOT NULL,            -- 사이트 이름
+    domain VARCHAR(255) NOT NULL DEFAULT '', -- 도메인 (옵션)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NULL
+);
+
+-- 페이지 그룹 테이블
+CREATE TABLE IF NOT EXISTS page_groups (
+    group_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    site_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NULL,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE,
+    UNIQUE (site_id, name)
+);
+
+-- 페이지와 메뉴 관리를 위한 테이블
+CREATE TABLE IF NOT EXISTS pages (
+    page_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    site_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    slug VARCHAR(255) NOT NULL DEFAULT '',
+    parent_id INTEGER NULL DEFAULT NULL,
+    depth INTEGER NOT NULL DEFAULT 0,
+    content TEXT NOT NULL DEFAULT '',
+    menu_order INTEGER NOT NULL DEFAULT 0,
+    is_published BOOLEAN DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NULL,
+    FOREIGN KEY (parent_id) REFERENCES pages(page_id) ON DELETE CASCADE,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES page_groups(group_id) ON DELETE CASCADE,
+    UNIQUE (site_id, slug, parent_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_id ON pages (group_id);
+
+-- MySQL 의 before_pages_update 트리거와 같은 동작
+CREATE TRIGGER IF NOT EXISTS after_pages_publish_update
+AFTER UPDATE OF is_published ON pages
+FOR EACH ROW WHEN NEW.is_published != OLD.is_published
+BEGIN
+    UPDATE pages SET updated_at = CURRENT_TIMESTAMP WHERE page_id = NEW.page_id;
+END;
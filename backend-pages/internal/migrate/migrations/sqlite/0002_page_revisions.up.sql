@@ -0,0 +1,16 @@
+-- 페이지 변경 이력 테이블
+CREATE TABLE IF NOT EXISTS page_revisions (
+    revision_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    page_id INTEGER NOT NULL,
+    revision INTEGER NOT NULL,             -- 페이지별 1부터 증가하는 리비전 번호
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    slug VARCHAR(255) NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (page_id) REFERENCES pages(page_id) ON DELETE CASCADE,
+    UNIQUE (page_id, revision)
+);
+
+-- 기존 페이지의 현재 내용을 첫 리비전으로 기록
+INSERT INTO page_revisions (page_id, revision, title, slug, content)
+SELECT page_id, 1, title, slug, content FROM pages;
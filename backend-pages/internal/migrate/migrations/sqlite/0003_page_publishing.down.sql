@@ -0,0 +1,2 @@
+ALTER TABLE pages DROP COLUMN published_at;
+ALTER TABLE pages DROP COLUMN published_revision;
@@ -0,0 +1,10 @@
+-- 공개 스냅샷: title, slug, content 는 작업 중인 초안이고,
+-- 공개된 내용은 published_revision 이 가리키는 page_revisions 행입니다.
+ALTER TABLE pages ADD COLUMN published_revision INTEGER NULL DEFAULT NULL;
+ALTER TABLE pages ADD COLUMN published_at TIMESTAMP NULL DEFAULT NULL;
+
+-- 기존 공개 페이지는 최신 리비전을 공개 스냅샷으로 지정
+UPDATE pages
+SET published_revision = (SELECT MAX(r.revision) FROM page_revisions r WHERE r.page_id = pages.page_id),
+    published_at = COALESCE(updated_at, created_at)
+WHERE is_published = 1;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_unpublish_at;
+DROP INDEX IF EXISTS idx_publish_at;
+ALTER TABLE pages DROP COLUMN unpublish_at;
+ALTER TABLE pages DROP COLUMN publish_at;
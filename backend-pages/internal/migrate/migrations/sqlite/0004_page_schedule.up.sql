@@ -0,0 +1,6 @@
+-- 예약 공개/만료 시각. 스케줄러가 처리하면 NULL 로 비웁니다.
+ALTER TABLE pages ADD COLUMN publish_at TIMESTAMP NULL DEFAULT NULL;
+ALTER TABLE pages ADD COLUMN unpublish_at TIMESTAMP NULL DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_publish_at ON pages (publish_at);
+CREATE INDEX IF NOT EXISTS idx_unpublish_at ON pages (unpublish_at);
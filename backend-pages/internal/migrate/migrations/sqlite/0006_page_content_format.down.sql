@@ -0,0 +1,2 @@
+ALTER TABLE page_revisions DROP COLUMN content_format;
+ALTER TABLE pages DROP COLUMN content_format;
@@ -0,0 +1,3 @@
+-- 본문 형식 (markdown, html, plain). 기존 본문은 HTML 로 간주합니다.
+ALTER TABLE pages ADD COLUMN content_format VARCHAR(16) NOT NULL DEFAULT 'html';
+ALTER TABLE page_revisions ADD COLUMN content_format VARCHAR(16) NOT NULL DEFAULT 'html';
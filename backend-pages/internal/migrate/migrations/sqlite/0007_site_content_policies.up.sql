@@ -0,0 +1,7 @@
+-- 사이트별 HTML 허용 목록 정책 (JSON, models.ContentPolicy)
+CREATE TABLE IF NOT EXISTS site_content_policies (
+    site_id INTEGER PRIMARY KEY,
+    policy TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+-- 사이트별 robots.txt. 없으면 모든 경로를 허용하고 sitemap 위치를 알리는 기본값을 씁니다.
+CREATE TABLE IF NOT EXISTS site_robots (
+    site_id INTEGER PRIMARY KEY,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE
+);
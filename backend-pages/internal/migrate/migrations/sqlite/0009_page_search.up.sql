@@ -0,0 +1,3 @@
+-- SQLite 는 FULLTEXT 인덱스 대신 프로세스 내 역색인(internal/search)으로 검색하므로 스키마 변경이 없습니다.
+-- MySQL 과 버전 번호를 맞추기 위한 빈 마이그레이션입니다.
+SELECT 1;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS site_locales;
+DROP TABLE IF EXISTS page_translations;
@@ -0,0 +1,27 @@
+-- 페이지 번역 테이블. pages 의 title, slug, content 는 사이트 기본 언어의 내용입니다.
+-- published_* 는 페이지를 공개할 때 초안에서 복사한 공개 스냅샷입니다.
+CREATE TABLE IF NOT EXISTS page_translations (
+    page_id INTEGER NOT NULL,
+    locale VARCHAR(35) NOT NULL,           -- BCP 47 언어 태그 (예: 'en', 'zh-Hant')
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    slug VARCHAR(255) NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    content_format VARCHAR(20) NOT NULL DEFAULT 'html',
+    published_title VARCHAR(255) NULL DEFAULT NULL,
+    published_slug VARCHAR(255) NULL DEFAULT NULL,
+    published_content TEXT NULL DEFAULT NULL,
+    published_content_format VARCHAR(20) NULL DEFAULT NULL,
+    published_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (page_id, locale),
+    FOREIGN KEY (page_id) REFERENCES pages(page_id) ON DELETE CASCADE
+);
+
+-- 사이트별 언어 설정 (JSON, models.SiteLocales)
+CREATE TABLE IF NOT EXISTS site_locales (
+    site_id INTEGER PRIMARY KEY,
+    settings TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (site_id) REFERENCES sites(site_id) ON DELETE CASCADE
+);
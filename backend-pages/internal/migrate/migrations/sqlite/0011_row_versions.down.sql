@@ -0,0 +1,3 @@
+ALTER TABLE pages DROP COLUMN version;
+ALTER TABLE page_groups DROP COLUMN version;
+ALTER TABLE sites DROP COLUMN version;
@@ -0,0 +1,13 @@
+-- API 키. 키 원문은 발급할 때 한 번만 응답하고, 여기에는 SHA-256 해시(hex)만 저장합니다.
+-- prefix 는 목록에서 키를 구분하기 위한 키의 앞부분입니다. 폐기한 키는 revoked_at 을 남기고 지우지 않습니다.
+CREATE TABLE IF NOT EXISTS api_keys (
+    key_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    is_admin BOOLEAN NOT NULL DEFAULT 0,   -- 관리 API(/api/admin) 사용 가능 여부
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
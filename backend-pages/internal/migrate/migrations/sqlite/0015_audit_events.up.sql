@@ -0,0 +1,17 @@
+-- 변경 이력. actor 는 인증 주체(subject), before_data, after_data 는 변경 전후의 JSON 스냅샷입니다.
+-- 사이트를 지운 뒤에도 이력을 볼 수 있도록 site_id 에 외래 키를 두지 않습니다.
+CREATE TABLE IF NOT EXISTS audit_events (
+    event_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor VARCHAR(255) NOT NULL,
+    actor_name VARCHAR(255) NOT NULL DEFAULT '',
+    site_id INTEGER NULL DEFAULT NULL,    -- API 키, 사용자처럼 사이트에 속하지 않는 대상은 NULL
+    entity_type VARCHAR(30) NOT NULL,     -- site, page_group, page, page_translation, ...
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,          -- create, update, delete, move, publish, unpublish
+    before_data TEXT NULL,
+    after_data TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_site_id ON audit_events (site_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events (entity_type, entity_id);
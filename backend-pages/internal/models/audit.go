@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 변경 이력의 대상 종류
+const (
+	EntitySite            = "site"
+	EntitySiteTheme       = "site_theme"
+	EntitySiteRobots      = "site_robots"
+	EntityContentPolicy   = "content_policy"
+	EntitySiteLocales     = "site_locales"
+	EntityPageGroup       = "page_group"
+	EntityPage            = "page"
+	EntityPageTranslation = "page_translation"
+	EntityAPIKey          = "api_key"
+	EntityUser            = "user"
+	EntityRoleGrant       = "role_grant"
+)
+
+// 변경 이력의 동작
+const (
+	AuditCreate    = "create"
+	AuditUpdate    = "update"
+	AuditDelete    = "delete"
+	AuditMove      = "move"
+	AuditPublish   = "publish"
+	AuditUnpublish = "unpublish"
+)
+
+// AuditActorScheduler 는 예약 공개/만료를 처리한 스케줄러의 이력 주체입니다.
+const AuditActorScheduler = "scheduler"
+
+// AuditEvent 는 변경 하나의 이력입니다. Actor 는 변경한 인증 주체(subject)이고, Before, After 는 변경 전후
+// 대상의 JSON 스냅샷입니다. 생성에는 Before 가, 삭제에는 After 가 없습니다.
+type AuditEvent struct {
+	EventID    int             `json:"event_id"`
+	Actor      string          `json:"actor"`
+	ActorName  string          `json:"actor_name"`
+	SiteID     *int            `json:"site_id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   int             `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+// APIKey 는 발급한 API 키의 정보입니다. 키 원문은 저장하지 않으며 Prefix 로 키를 구분합니다.
+// Admin 키는 super-admin 으로 모든 사이트와 관리 API(/api/admin)를 쓸 수 있습니다.
+type APIKey struct {
+	KeyID      int        `json:"key_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Admin      bool       `json:"admin"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Active 는 now 시점에 키로 인증할 수 있는지(폐기되지 않았고 만료되지 않았는지) 반환합니다.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// IssuedAPIKey 는 발급 응답입니다. Key 는 이 응답에서만 볼 수 있습니다.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type CreateAPIKeyInput struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Admin     bool       `json:"admin"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// User 는 역할을 줄 수 있는 사용자입니다. Subject 는 인증 주체로, JWT 의 sub 이거나
+// API 키의 "apikey:<key_id>" 입니다.
+type User struct {
+	UserID    int         `json:"user_id"`
+	Subject   string      `json:"subject"`
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"created_at"`
+	Grants    []RoleGrant `json:"grants,omitempty"`
+}
+
+// RoleGrant 는 사용자에게 준 역할 하나입니다. SiteID, GroupID 가 모두 없으면 전체(super-admin),
+// SiteID 만 있으면 사이트 전체, GroupID 도 있으면 그 그룹에만 적용됩니다.
+type RoleGrant struct {
+	GrantID   int       `json:"grant_id"`
+	UserID    int       `json:"user_id"`
+	Role      string    `json:"role"`
+	SiteID    *int      `json:"site_id"`
+	SiteCode  *string   `json:"site_code"`
+	GroupID   *int      `json:"group_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateUserInput struct {
+	Subject string `json:"subject" validate:"required,max=255"`
+	Name    string `json:"name" validate:"max=255"`
+}
+
+// CreateRoleGrantInput 은 역할 부여 요청입니다. super-admin 은 site_code 없이, site-admin 은
+// site_code 만, 나머지 역할은 site_code 와 선택적으로 그 사이트의 group_id 를 줍니다.
+type CreateRoleGrantInput struct {
+	Role     string `json:"role" validate:"required,oneof=viewer editor publisher site-admin super-admin"`
+	SiteCode string `json:"site_code"`
+	GroupID  *int   `json:"group_id"`
+}
+
+// Permissions 는 인증된 사용자의 권한입니다. 관리 화면은 Actions 에 없는 동작의 컨트롤을 숨깁니다.
+type Permissions struct {
+	Subject    string            `json:"subject"`
+	Name       string            `json:"name"`
+	Method     string            `json:"method"`
+	SuperAdmin bool              `json:"super_admin"`
+	Sites      []SitePermissions `json:"sites"`
+}
+
+// SitePermissions 는 사이트 하나의 권한입니다. Role 은 사이트 전체에 받은 역할(없으면 빈 문자열)이고,
+// Groups 는 그룹에만 받은 역할로 사이트 역할보다 높은 그룹입니다.
+type SitePermissions struct {
+	SiteID   int                `json:"site_id"`
+	SiteCode string             `json:"site_code"`
+	Role     string             `json:"role"`
+	Actions  []string           `json:"actions"`
+	Groups   []GroupPermissions `json:"groups"`
+}
+
+type GroupPermissions struct {
+	GroupID int      `json:"group_id"`
+	Role    string   `json:"role"`
+	Actions []string `json:"actions"`
+}
@@ -9,8 +9,11 @@ type Site struct {
 	Code      string     `json:"code"`
 	Name      string     `json:"name"`
 	Domain    *string    `json:"domain"`
+	Version   int        `json:"version"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
+	// Aliases 는 바꾸기 전의 코드입니다. 사이트 단건 조회에서만 채웁니다.
+	Aliases []string `json:"aliases,omitempty"`
 }
 
 // MarshalJSON implements custom JSON marshaling for Site
@@ -37,56 +40,388 @@ type PageGroup struct {
 	SiteID      int        `json:"site_id"`
 	Name        string     `json:"name"`
 	Description string     `json:"description"`
+	Version     int        `json:"version"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   *time.Time `json:"updated_at"`
 	Menu        []*Page    `json:"menu"`
 }
 
+// 본문 형식 (Page.ContentFormat)
+const (
+	ContentFormatMarkdown = "markdown"
+	ContentFormatHTML     = "html"
+	ContentFormatPlain    = "plain"
+)
+
+// ValidContentFormat 은 format 이 지원하는 본문 형식인지 반환합니다.
+func ValidContentFormat(format string) bool {
+	switch format {
+	case ContentFormatMarkdown, ContentFormatHTML, ContentFormatPlain:
+		return true
+	}
+	return false
+}
+
+// Page 의 Title, Slug, Content 는 작업 중인 초안입니다.
+// 공개된 내용은 Published 스냅샷(PublishedRevision 리비전)에 있습니다.
+// PublishAt 이 되면 그 시점의 초안이 공개되고, UnpublishAt 이 되면 비공개로 바뀝니다.
+// Revision 은 Content 가 기록된 리비전 번호이며, ContentHTML 은 Content 를 ContentFormat 에 따라
+// 렌더링하고 정제한 HTML 입니다(GetPage, resolve 응답에서만 채워집니다).
+// ContentIssues 는 사이트 정책이 flag 모드일 때 정제 과정에서 제거될 항목입니다.
+// Locale 은 언어별 조회(menu, GetPage, resolve)에서 Title, Slug, Content 를 가져온 언어입니다.
+// 번역이 적용되면 Revision 은 0 입니다(번역에는 리비전이 없습니다).
+// Version 은 페이지나 번역이 바뀔 때마다 올라가는 행 버전으로 ETag 와 If-Match 에 씁니다.
 type Page struct {
-	PageID      int        `json:"page_id"`
-	SiteID      int        `json:"site_id"`
-	GroupID     int        `json:"group_id"`
-	Title       string     `json:"title"`
-	Slug        string     `json:"slug"`
-	ParentID    *int       `json:"parent_id"`
-	Depth       int        `json:"depth"`
-	MenuOrder   int        `json:"menu_order"`
-	Content     string     `json:"content"`
-	IsPublished bool       `json:"is_published"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at"`
-	Menu        []*Page    `json:"menu"`
+	PageID            int            `json:"page_id"`
+	SiteID            int            `json:"site_id"`
+	GroupID           int            `json:"group_id"`
+	Title             string         `json:"title"`
+	Slug              string         `json:"slug"`
+	ParentID          *int           `json:"parent_id"`
+	Depth             int            `json:"depth"`
+	MenuOrder         int            `json:"menu_order"`
+	Content           string         `json:"content"`
+	ContentFormat     string         `json:"content_format"`
+	ContentHTML       string         `json:"content_html,omitempty"`
+	ContentIssues     []ContentIssue `json:"content_issues,omitempty"`
+	Revision          int            `json:"revision"`
+	Locale            string         `json:"locale,omitempty"`
+	IsPublished       bool           `json:"is_published"`
+	PublishedRevision *int           `json:"published_revision"`
+	PublishedAt       *time.Time     `json:"published_at"`
+	Published         *PageRevision  `json:"published,omitempty"`
+	PublishAt         *time.Time     `json:"publish_at"`
+	UnpublishAt       *time.Time     `json:"unpublish_at"`
+	Version           int            `json:"version"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         *time.Time     `json:"updated_at"`
+	Menu              []*Page        `json:"menu"`
+}
+
+// IsLive 는 now 시점에 공개 스냅샷이 노출되어야 하는지 반환합니다.
+// 스케줄러가 아직 처리하지 않았더라도 UnpublishAt 이 지난 페이지는 노출하지 않습니다.
+func (p *Page) IsLive(now time.Time) bool {
+	if !p.IsPublished || p.Published == nil {
+		return false
+	}
+	return p.UnpublishAt == nil || now.Before(*p.UnpublishAt)
+}
+
+// PublishedView 는 Title, Slug, Content 를 공개 스냅샷으로 바꾼 사본을 반환합니다.
+// now 시점에 노출되지 않는 페이지면 nil 을 반환합니다.
+func (p *Page) PublishedView(now time.Time) *Page {
+	if !p.IsLive(now) {
+		return nil
+	}
+	view := *p
+	view.Title = p.Published.Title
+	view.Slug = p.Published.Slug
+	view.Content = p.Published.Content
+	view.ContentFormat = p.Published.ContentFormat
+	view.ContentHTML = ""
+	view.ContentIssues = nil
+	view.Revision = p.Published.Revision
+	view.Published = nil
+	view.Menu = nil
+	return &view
+}
+
+// PageTranslation 은 페이지의 언어별 title, slug, content 입니다.
+// pages 의 내용은 사이트 기본 언어이며, 기본 언어가 아닌 언어만 번역으로 저장합니다.
+// Published 는 페이지를 공개할 때 그 시점의 번역 초안을 복사한 공개 스냅샷입니다.
+type PageTranslation struct {
+	PageID        int                   `json:"page_id"`
+	Locale        string                `json:"locale"`
+	Title         string                `json:"title"`
+	Slug          string                `json:"slug"`
+	Content       string                `json:"content"`
+	ContentFormat string                `json:"content_format"`
+	Published     *PublishedTranslation `json:"published,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     *time.Time            `json:"updated_at"`
+}
+
+type PublishedTranslation struct {
+	Title         string    `json:"title"`
+	Slug          string    `json:"slug"`
+	Content       string    `json:"content"`
+	ContentFormat string    `json:"content_format"`
+	PublishedAt   time.Time `json:"published_at"`
+}
+
+// SiteLocales 는 사이트별 언어 설정입니다. Fallbacks 는 언어별로 번역이 없을 때 차례로 시도할 언어이며,
+// 목록이 끝나면 DefaultLocale(페이지 원문)을 씁니다.
+type SiteLocales struct {
+	DefaultLocale string              `json:"default_locale" validate:"required"`
+	Locales       []string            `json:"locales" validate:"max=50"`
+	Fallbacks     map[string][]string `json:"fallbacks"`
+	UpdatedAt     *time.Time          `json:"updated_at,omitempty"`
+}
+
+// TranslationReport 는 언어별로 번역이 없는 페이지 목록입니다.
+type TranslationReport struct {
+	DefaultLocale string               `json:"default_locale"`
+	Locales       []LocaleTranslations `json:"locales"`
+}
+
+type LocaleTranslations struct {
+	Locale  string               `json:"locale"`
+	Total   int                  `json:"total"`
+	Missing []MissingTranslation `json:"missing"`
+}
+
+// MissingTranslation 의 Reason 은 번역이 아예 없으면 missing, 공개 중인 페이지의 번역이
+// 아직 공개되지 않았으면 unpublished 입니다. 두 경우 모두 fallback 언어로 응답합니다.
+type MissingTranslation struct {
+	PageID  int    `json:"page_id"`
+	GroupID int    `json:"group_id"`
+	Title   string `json:"title"`
+	Slug    string `json:"slug"`
+	Reason  string `json:"reason"`
+}
+
+// 번역 누락 사유 (MissingTranslation.Reason)
+const (
+	TranslationMissing     = "missing"
+	TranslationUnpublished = "unpublished"
+)
+
+// SiteTheme 은 공개 사이트 렌더링에 쓰는 사이트별 html/template 레이아웃입니다.
+type SiteTheme struct {
+	SiteID    int       `json:"site_id"`
+	Template  string    `json:"template"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SiteRobots 는 사이트별 robots.txt 내용입니다.
+type SiteRobots struct {
+	SiteID    int        `json:"site_id"`
+	Content   string     `json:"content"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// 본문 정책 모드 (ContentPolicy.Mode)
+const (
+	PolicyModeSanitize = "sanitize" // 저장할 때 허용되지 않은 항목을 제거
+	PolicyModeFlag     = "flag"     // 그대로 저장하고 조회할 때 content_issues 로 표시
+)
+
+// ContentPolicy 는 사이트별 HTML 허용 목록입니다. 목록에 없는 요소와 속성, 스킴은 제거됩니다.
+// content_html 은 모드와 관계없이 항상 이 정책으로 정제됩니다.
+type ContentPolicy struct {
+	Mode       string              `json:"mode" validate:"oneof=sanitize flag"`
+	Elements   []string            `json:"elements"`
+	Attributes map[string][]string `json:"attributes"`  // 요소별 허용 속성, "*" 는 모든 요소
+	URLSchemes []string            `json:"url_schemes"` // href, src 등에 허용할 스킴. 상대 URL 은 항상 허용
+	UpdatedAt  *time.Time          `json:"updated_at,omitempty"`
+}
+
+// ContentIssue 는 정책에 따라 제거되는 항목입니다.
+type ContentIssue struct {
+	Kind      string `json:"kind"` // element, attribute, url
+	Element   string `json:"element"`
+	Attribute string `json:"attribute,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+// PageRevision 은 페이지 생성/수정 시점의 title, slug, content, content_format 스냅샷입니다.
+type PageRevision struct {
+	PageID        int       `json:"page_id"`
+	Revision      int       `json:"revision"`
+	Title         string    `json:"title"`
+	Slug          string    `json:"slug"`
+	Content       string    `json:"content"`
+	ContentFormat string    `json:"content_format"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RevisionDiff 는 두 리비전 사이에서 값이 달라진 필드 목록입니다.
+type RevisionDiff struct {
+	PageID  int           `json:"page_id"`
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type Breadcrumb struct {
+	PageID int    `json:"page_id"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	Path   string `json:"path"`
+}
+
+// ResolveResult 는 slug 경로 조회 결과입니다. 경로를 끝까지 찾지 못하면 Page 는 비어 있고
+// Breadcrumb 와 DeepestMatch 는 가장 깊이 일치한 조상까지를 나타냅니다.
+type ResolveResult struct {
+	Path         string       `json:"path"`
+	Page         *Page        `json:"page,omitempty"`
+	Breadcrumb   []Breadcrumb `json:"breadcrumb"`
+	DeepestMatch *Breadcrumb  `json:"deepest_match,omitempty"`
+}
+
+// SearchQuery 는 페이지 검색 조건입니다. SiteID, GroupID 가 0 이면 전체를 대상으로 합니다.
+// Published 가 true 이면 공개 중인 페이지의 공개 스냅샷을, 그 밖에는 초안을 검색합니다.
+type SearchQuery struct {
+	Text      string
+	Terms     []string // Text 를 search.Tokenize 로 나눈 검색어. 모두 접두어로 일치해야 합니다.
+	SiteID    int
+	GroupID   int
+	Published *bool
+	Limit     int
+	Offset    int
+}
+
+// SearchHit 은 검색 결과 한 건입니다. Title 과 Snippet 은 검색어를 <mark> 로 감싼 HTML 입니다.
+type SearchHit struct {
+	PageID      int     `json:"page_id"`
+	SiteID      int     `json:"site_id"`
+	GroupID     int     `json:"group_id"`
+	Slug        string  `json:"slug"`
+	IsPublished bool    `json:"is_published"`
+	Title       string  `json:"title"`
+	Snippet     string  `json:"snippet"`
+	Score       float64 `json:"score"`
+
+	// 강조 처리 전의 본문. 응답에는 포함하지 않습니다.
+	Content       string `json:"-"`
+	ContentFormat string `json:"-"`
+}
+
+type SearchResult struct {
+	Query string      `json:"query"`
+	Total int         `json:"total"`
+	Hits  []SearchHit `json:"hits"`
 }
 
 type CreateSiteInput struct {
-	Code   string `json:"code"`
-	Name   string `json:"name"`
-	Domain string `json:"domain"`
+	Code   string `json:"code" validate:"required,sitecode"`
+	Name   string `json:"name" validate:"required,max=255"`
+	Domain string `json:"domain" validate:"hostname,max=255"`
 }
 
 type CreatePageInput struct {
-	Title    string `json:"title"`
-	Slug     string `json:"slug"`
-	ParentID *int   `json:"parent_id,omitempty"`
-	Content  string `json:"content"`
+	Title         string     `json:"title" validate:"required,max=255"`
+	Slug          string     `json:"slug" validate:"required,slug,max=255"`
+	ParentID      *int       `json:"parent_id,omitempty"`
+	Content       string     `json:"content" validate:"maxbytes=65535"`
+	ContentFormat string     `json:"content_format,omitempty" validate:"oneof=markdown html plain"` // 생략하면 html
+	PublishAt     *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt   *time.Time `json:"unpublish_at,omitempty"`
+}
+
+// SanitizeDryRunInput 은 정제 결과 미리보기 요청입니다. Policy 를 생략하면 사이트 정책을 사용합니다.
+type SanitizeDryRunInput struct {
+	Content       string         `json:"content" validate:"maxbytes=65535"`
+	ContentFormat string         `json:"content_format,omitempty" validate:"oneof=markdown html plain"`
+	Policy        *ContentPolicy `json:"policy,omitempty"`
+}
+
+type SanitizeDryRunResult struct {
+	ContentHTML string         `json:"content_html"`
+	Changed     bool           `json:"changed"`
+	Removed     []ContentIssue `json:"removed"`
+}
+
+type SaveTranslationInput struct {
+	Title         string `json:"title" validate:"required,max=255"`
+	Slug          string `json:"slug" validate:"required,slug,max=255"`
+	Content       string `json:"content" validate:"maxbytes=65535"`
+	ContentFormat string `json:"content_format,omitempty" validate:"oneof=markdown html plain"` // 생략하면 html
+}
+
+type SaveSiteRobotsInput struct {
+	Content string `json:"content" validate:"maxbytes=65535"`
+}
+
+type SaveSiteThemeInput struct {
+	Template string `json:"template" validate:"required,maxbytes=1048576"`
 }
 
 type CreatePageGroupInput struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"maxbytes=65535"`
 }
 
+// UpdatePageInput 은 PUT 으로 바꾸는 페이지 필드입니다. 위치(parent_id, menu_order)는 move 로,
+// 공개 상태는 publish, unpublish 로 바꾸며 PUT 에 보내면 모르는 필드로 거부합니다.
 type UpdatePageInput struct {
-	Title       string `json:"title"`
-	Slug        string `json:"slug"`
-	ParentID    *int64 `json:"parent_id,omitempty"`
-	Depth       int    `json:"depth,omitempty"`
-	MenuOrder   int    `json:"menu_order,omitempty"`
-	Content     string `json:"content,omitempty"`
-	IsPublished bool   `json:"is_published,omitempty"`
+	Title         string     `json:"title" validate:"required,max=255"`
+	Slug          string     `json:"slug" validate:"required,slug,max=255"`
+	Content       string     `json:"content,omitempty" validate:"maxbytes=65535"`
+	ContentFormat string     `json:"content_format,omitempty" validate:"oneof=markdown html plain"` // 생략하면 기존 형식 유지
+	PublishAt     *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt   *time.Time `json:"unpublish_at,omitempty"`
+}
+
+// MovePageInput 은 페이지를 옮길 부모와 위치입니다. ParentID 가 nil 이면 최상위로 옮깁니다.
+// BeforeID 나 AfterID 로 기준 형제를 지정하며, 둘 다 없으면 형제 중 마지막에 둡니다.
+type MovePageInput struct {
+	ParentID *int `json:"parent_id"`
+	BeforeID *int `json:"before_id,omitempty"`
+	AfterID  *int `json:"after_id,omitempty"`
+}
+
+// TreeNodeInput 은 메뉴 트리 저장 요청의 노드입니다.
+// pagetree.BuildMenuTree 가 만든 Page 트리(page_id, menu)를 그대로 받을 수 있으며 나머지 필드는 무시합니다.
+type TreeNodeInput struct {
+	PageID int             `json:"page_id" validate:"required"`
+	Menu   []TreeNodeInput `json:"menu"`
 }
 
 type UpdatePageGroupInput struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"maxbytes=65535"`
+}
+
+// PatchPageInput 은 PATCH(JSON merge patch, RFC 7396)로 바꿀 수 있는 페이지 필드입니다.
+// 현재 값에 patch 를 적용한 결과를 검사하고, patch 에 있는 필드만 저장합니다.
+// null 은 필드를 지웁니다. parent_id 는 최상위로, menu_order 는 형제 중 마지막으로,
+// is_published 는 비공개로 바뀝니다. menu_order 는 새 부모의 형제 중 위치(1부터)입니다.
+type PatchPageInput struct {
+	Title         string     `json:"title" validate:"required,max=255"`
+	Slug          string     `json:"slug" validate:"required,slug,max=255"`
+	Content       string     `json:"content" validate:"maxbytes=65535"`
+	ContentFormat string     `json:"content_format" validate:"required,oneof=markdown html plain"`
+	IsPublished   bool       `json:"is_published"`
+	ParentID      *int       `json:"parent_id"`
+	MenuOrder     int        `json:"menu_order" validate:"min=1"`
+	PublishAt     *time.Time `json:"publish_at"`
+	UnpublishAt   *time.Time `json:"unpublish_at"`
+}
+
+// PatchPageGroupInput 은 PATCH(JSON merge patch)로 바꿀 수 있는 페이지 그룹 필드입니다.
+type PatchPageGroupInput struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"maxbytes=65535"`
+}
+
+// PatchSiteInput 은 PATCH(JSON merge patch)로 바꿀 수 있는 사이트 필드입니다. domain 을 null 로 보내면 지웁니다.
+type PatchSiteInput struct {
+	Name   string  `json:"name" validate:"required,max=255"`
+	Domain *string `json:"domain" validate:"hostname,max=255"`
+}
+
+// UpdateSiteInput 은 PUT 으로 바꾸는 사이트 필드입니다. 코드는 RenameSiteInput 으로 바꿉니다.
+type UpdateSiteInput struct {
+	Name   string  `json:"name" validate:"required,max=255"`
+	Domain *string `json:"domain" validate:"hostname,max=255"`
+}
+
+// RenameSiteInput 은 사이트의 새 코드입니다. 이전 코드는 별칭으로 남아 새 코드로 리다이렉트됩니다.
+type RenameSiteInput struct {
+	Code string `json:"code" validate:"required,sitecode"`
+}
+
+// SiteDeletion 은 사이트를 지울 때 함께 지워지는 그룹과 페이지 수입니다.
+type SiteDeletion struct {
+	SiteID     int    `json:"site_id"`
+	Code       string `json:"code"`
+	PageGroups int    `json:"page_groups"`
+	Pages      int    `json:"pages"`
 }
@@ -0,0 +1,139 @@
+package pagetree
+
+import (
+	"pages/internal/models"
+	"strings"
+	"time"
+)
+
+// BuildMenuTree 는 페이지 목록을 parent_id 기준의 트리로 묶어 최상위 페이지 목록을 반환합니다.
+// 각 페이지의 Menu 에 하위 페이지가 채워지며, 부모가 목록에 없는 페이지는 제외됩니다.
+func BuildMenuTree(pages []*models.Page) []*models.Page {
+	pageMap := make(map[int]*models.Page)
+
+	// 모든 페이지를 맵에 저장
+	for _, page := range pages {
+		page.Menu = []*models.Page{} // 초기화
+		pageMap[page.PageID] = page
+	}
+
+	// 트리 구성
+	var roots []*models.Page
+	for _, page := range pages {
+		if page.ParentID != nil {
+			if parent, exists := pageMap[*page.ParentID]; exists {
+				parent.Menu = append(parent.Menu, page)
+			}
+		} else {
+			roots = append(roots, page)
+		}
+	}
+
+	return roots
+}
+
+// Published 는 now 시점에 노출되는 페이지만 골라 공개 스냅샷 내용으로 바꾼 사본을 반환합니다.
+// 비공개 부모의 하위 페이지는 BuildMenuTree 에서 제외됩니다.
+func Published(pages []*models.Page, now time.Time) []*models.Page {
+	published := []*models.Page{}
+	for _, page := range pages {
+		if view := page.PublishedView(now); view != nil {
+			published = append(published, view)
+		}
+	}
+	return published
+}
+
+// Segments 는 "/service/pricing/" 같은 경로를 빈 조각 없이 slug 목록으로 나눕니다.
+func Segments(path string) []string {
+	var segments []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+// Resolve 는 최상위 페이지부터 slug 경로를 따라 내려가며 일치한 페이지를 순서대로 반환합니다.
+// 경로 전체가 일치하면 ok 는 true 이고 trail 의 마지막 원소가 대상 페이지입니다.
+// 중간에 끊기면 ok 는 false 이고 trail 은 가장 깊이 일치한 조상까지입니다.
+func Resolve(pages []*models.Page, path string) (trail []*models.Page, ok bool) {
+	var parentID *int
+	for _, slug := range Segments(path) {
+		next := findChild(pages, parentID, slug)
+		if next == nil {
+			return trail, false
+		}
+		trail = append(trail, next)
+		parentID = &next.PageID
+	}
+	return trail, len(trail) > 0
+}
+
+func findChild(pages []*models.Page, parentID *int, slug string) *models.Page {
+	for _, p := range pages {
+		if p.Slug != slug {
+			continue
+		}
+		if (parentID == nil && p.ParentID == nil) || (parentID != nil && p.ParentID != nil && *p.ParentID == *parentID) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Breadcrumbs 는 trail 의 각 페이지에 누적 경로를 붙여 반환합니다.
+func Breadcrumbs(trail []*models.Page) []models.Breadcrumb {
+	crumbs := make([]models.Breadcrumb, 0, len(trail))
+	path := ""
+	for _, p := range trail {
+		path += "/" + p.Slug
+		crumbs = append(crumbs, models.Breadcrumb{
+			PageID: p.PageID,
+			Title:  p.Title,
+			Slug:   p.Slug,
+			Path:   path,
+		})
+	}
+	return crumbs
+}
+
+// Path 는 page 의 부모를 따라 올라가며 "/service/pricing" 형식의 전체 경로를 만듭니다.
+// 부모가 pages 에 없으면 그 지점에서 멈춥니다.
+func Path(pages []*models.Page, page *models.Page) string {
+	byID := make(map[int]*models.Page, len(pages))
+	for _, p := range pages {
+		byID[p.PageID] = p
+	}
+
+	var slugs []string
+	for p := page; p != nil; {
+		slugs = append([]string{p.Slug}, slugs...)
+		if p.ParentID == nil || len(slugs) > len(pages) {
+			break
+		}
+		p = byID[*p.ParentID]
+	}
+	return "/" + strings.Join(slugs, "/")
+}
+
+// Walk 는 BuildMenuTree 가 만든 트리를 깊이 우선으로 돌며 각 페이지와 전체 경로로 fn 을 호출합니다.
+// 같은 경로의 페이지가 여럿이면 Resolve 와 마찬가지로 먼저 나온 페이지만 방문하고,
+// 가려진 페이지의 하위 페이지도 방문하지 않습니다.
+func Walk(roots []*models.Page, fn func(page *models.Page, path string)) {
+	seen := make(map[string]bool)
+	var walk func(pages []*models.Page, parentPath string)
+	walk = func(pages []*models.Page, parentPath string) {
+		for _, p := range pages {
+			path := parentPath + "/" + p.Slug
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			fn(p, path)
+			walk(p.Menu, path)
+		}
+	}
+	walk(roots, "")
+}
@@ -0,0 +1,286 @@
+// Package rbac 는 사용자 역할로 요청의 권한을 판단합니다.
+//
+// 역할은 viewer < editor < publisher < site-admin < super-admin 순으로 높으며, 높은 역할은 낮은 역할의
+// 동작을 모두 할 수 있습니다. super-admin 은 전체에, site-admin 은 사이트에, 나머지 역할은 사이트 전체나
+// 그 사이트의 페이지 그룹 하나에 줍니다. 그룹 안의 동작은 사이트 역할과 그룹 역할 중 높은 역할로 판단합니다.
+// 관리 권한(admin 키나 admin 클레임)으로 인증한 요청은 super-admin 입니다.
+package rbac
+
+import (
+	"context"
+	"net/http"
+	"pages/internal/auth"
+	"pages/internal/models"
+	"pages/internal/store"
+	"pages/pkg/response"
+	"sort"
+)
+
+// 역할
+const (
+	RoleViewer     = "viewer"
+	RoleEditor     = "editor"
+	RolePublisher  = "publisher"
+	RoleSiteAdmin  = "site-admin"
+	RoleSuperAdmin = "super-admin"
+)
+
+// roles 는 낮은 역할부터 나열한 역할입니다. 역할의 수준은 이 순서의 위치 + 1 이며 역할이 없으면 0 입니다.
+var roles = []string{RoleViewer, RoleEditor, RolePublisher, RoleSiteAdmin, RoleSuperAdmin}
+
+func level(role string) int {
+	for i, r := range roles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func roleName(lv int) string {
+	if lv == 0 {
+		return ""
+	}
+	return roles[lv-1]
+}
+
+// Action 은 권한을 확인하는 동작입니다.
+type Action string
+
+const (
+	// ActionAccess 는 사이트 정보, 그룹 목록, 공개 메뉴처럼 사이트의 역할이 하나라도 있으면 볼 수 있는 읽기입니다.
+	ActionAccess Action = "access"
+	// ActionView 는 페이지 초안과 리비전 읽기입니다.
+	ActionView Action = "view"
+	// ActionEdit 는 페이지 작성, 수정, 이동, 삭제입니다.
+	ActionEdit Action = "edit"
+	// ActionPublish 는 페이지 공개와 공개 취소, 예약 공개/만료 시각(publish_at, unpublish_at) 지정입니다.
+	ActionPublish Action = "publish"
+	// ActionManage 는 사이트 설정과 페이지 그룹 관리입니다.
+	ActionManage Action = "manage"
+	// ActionAdmin 은 사이트 생성, API 키와 사용자 관리처럼 사이트 밖의 관리입니다.
+	ActionAdmin Action = "admin"
+)
+
+// actions 는 동작과 그 동작에 필요한 최소 역할입니다.
+var actions = []struct {
+	action Action
+	role   string
+}{
+	{ActionView, RoleViewer},
+	{ActionEdit, RoleEditor},
+	{ActionPublish, RolePublisher},
+	{ActionManage, RoleSiteAdmin},
+	{ActionAdmin, RoleSuperAdmin},
+}
+
+// Actions 는 role 로 할 수 있는 동작 이름을 반환합니다. ActionAccess 는 포함하지 않습니다.
+func Actions(role string) []string {
+	names := []string{}
+	for _, a := range actions {
+		if level(role) >= level(a.role) {
+			names = append(names, string(a.action))
+		}
+	}
+	return names
+}
+
+// ValidScope 는 role 을 사이트 siteID, 그룹 groupID(0 이면 없음) 범위에 줄 수 있는지 반환합니다.
+func ValidScope(role string, siteID, groupID int) bool {
+	switch role {
+	case RoleSuperAdmin:
+		return siteID == 0 && groupID == 0
+	case RoleSiteAdmin:
+		return siteID != 0 && groupID == 0
+	}
+	return level(role) > 0 && siteID != 0
+}
+
+type groupRole struct {
+	siteID int
+	level  int
+}
+
+// Permissions 는 한 요청 주체의 역할을 모은 것입니다.
+type Permissions struct {
+	superAdmin bool
+	sites      map[int]int       // site_id → 사이트 역할 수준
+	groups     map[int]groupRole // group_id → 그룹 역할 수준
+	codes      map[int]string    // site_id → 사이트 코드
+}
+
+// New 는 grants 로 권한을 만듭니다. superAdmin 이면 역할과 상관없이 모든 동작을 허용합니다.
+func New(grants []models.RoleGrant, superAdmin bool) *Permissions {
+	p := &Permissions{
+		superAdmin: superAdmin,
+		sites:      map[int]int{},
+		groups:     map[int]groupRole{},
+		codes:      map[int]string{},
+	}
+	for _, g := range grants {
+		lv := level(g.Role)
+		switch {
+		case g.Role == RoleSuperAdmin:
+			p.superAdmin = true
+		case g.SiteID == nil:
+			continue
+		case g.GroupID != nil:
+			if cur := p.groups[*g.GroupID]; lv > cur.level {
+				p.groups[*g.GroupID] = groupRole{siteID: *g.SiteID, level: lv}
+			}
+		default:
+			p.sites[*g.SiteID] = max(p.sites[*g.SiteID], lv)
+		}
+		if g.SiteID != nil && g.SiteCode != nil {
+			p.codes[*g.SiteID] = *g.SiteCode
+		}
+	}
+	return p
+}
+
+// SuperAdmin 은 모든 사이트의 모든 동작을 할 수 있는지 반환합니다.
+func (p *Permissions) SuperAdmin() bool {
+	return p.superAdmin
+}
+
+// member 는 사이트 siteID 에 사이트나 그룹 역할이 있는지 반환합니다.
+func (p *Permissions) member(siteID int) bool {
+	if p.sites[siteID] > 0 {
+		return true
+	}
+	for _, g := range p.groups {
+		if g.siteID == siteID {
+			return true
+		}
+	}
+	return false
+}
+
+// level 은 사이트 siteID 의 그룹 groupID(0 이면 사이트 전체)에서의 역할 수준입니다.
+func (p *Permissions) level(siteID, groupID int) int {
+	lv := p.sites[siteID]
+	if g, ok := p.groups[groupID]; ok && groupID != 0 && g.siteID == siteID {
+		lv = max(lv, g.level)
+	}
+	return lv
+}
+
+// Can 은 사이트 siteID 의 그룹 groupID 에서 action 을 할 수 있는지 반환합니다. groupID 가 0 이면
+// 사이트 전체(모든 그룹)에 대한 권한이 필요합니다. ActionAccess 는 groupID 와 상관없이 사이트의
+// 역할이 하나라도 있으면 허용하고, ActionAdmin 은 super-admin 만 허용합니다.
+func (p *Permissions) Can(action Action, siteID, groupID int) bool {
+	if p.superAdmin {
+		return true
+	}
+	if action == ActionAccess {
+		return p.member(siteID)
+	}
+	for _, a := range actions {
+		if a.action == action {
+			return a.role != RoleSuperAdmin && p.level(siteID, groupID) >= level(a.role)
+		}
+	}
+	return false
+}
+
+// SiteIDs 는 역할이 있는 사이트 ID 를 오름차순으로 반환합니다. super-admin 이면 모든 사이트를 볼 수 있으므로 쓰지 않습니다.
+func (p *Permissions) SiteIDs() []int {
+	seen := map[int]bool{}
+	for id := range p.sites {
+		seen[id] = true
+	}
+	for _, g := range p.groups {
+		seen[g.siteID] = true
+	}
+	return sortedKeys(seen)
+}
+
+// GroupIDs 는 사이트 siteID 에서 그룹 역할을 받은 그룹 ID 를 오름차순으로 반환합니다.
+func (p *Permissions) GroupIDs(siteID int) []int {
+	seen := map[int]bool{}
+	for id, g := range p.groups {
+		if g.siteID == siteID {
+			seen[id] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+func sortedKeys(m map[int]bool) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// Describe 는 principal 의 권한을 관리 화면이 쓸 수 있는 형식으로 바꿉니다. 그룹은 사이트 역할보다
+// 높은 역할을 받은 그룹만 나열합니다.
+func (p *Permissions) Describe(principal *auth.Principal) *models.Permissions {
+	perms := &models.Permissions{SuperAdmin: p.superAdmin, Sites: []models.SitePermissions{}}
+	if principal != nil {
+		perms.Subject, perms.Name, perms.Method = principal.Subject, principal.Name, principal.Method
+	}
+	for _, siteID := range p.SiteIDs() {
+		site := models.SitePermissions{
+			SiteID:   siteID,
+			SiteCode: p.codes[siteID],
+			Role:     roleName(p.sites[siteID]),
+			Actions:  Actions(roleName(p.sites[siteID])),
+			Groups:   []models.GroupPermissions{},
+		}
+		for _, groupID := range p.GroupIDs(siteID) {
+			lv := p.level(siteID, groupID)
+			if lv <= p.sites[siteID] {
+				continue
+			}
+			site.Groups = append(site.Groups, models.GroupPermissions{
+				GroupID: groupID,
+				Role:    roleName(lv),
+				Actions: Actions(roleName(lv)),
+			})
+		}
+		perms.Sites = append(perms.Sites, site)
+	}
+	return perms
+}
+
+type contextKey struct{}
+
+func WithPermissions(ctx context.Context, perms *Permissions) context.Context {
+	return context.WithValue(ctx, contextKey{}, perms)
+}
+
+// FromContext 는 Middleware 가 저장한 권한을 반환합니다. 없으면 아무 동작도 허용하지 않는 권한입니다.
+func FromContext(ctx context.Context) *Permissions {
+	if perms, ok := ctx.Value(contextKey{}).(*Permissions); ok {
+		return perms
+	}
+	return New(nil, false)
+}
+
+// Middleware 는 인증한 요청 주체의 역할을 읽어 권한을 컨텍스트에 담습니다. auth 의 Middleware 뒤에 씁니다.
+// 관리 권한으로 인증한 요청은 역할을 읽지 않고 super-admin 으로 봅니다.
+func Middleware(access store.AccessStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := auth.FromContext(r.Context())
+			if principal == nil {
+				response.Error(w, r, response.NewError(http.StatusUnauthorized, response.CodeUnauthorized))
+				return
+			}
+
+			var grants []models.RoleGrant
+			if !principal.Admin {
+				var err error
+				if grants, err = access.ListGrantsBySubject(r.Context(), principal.Subject); err != nil {
+					response.Error(w, r, err)
+					return
+				}
+			}
+			perms := New(grants, principal.Admin)
+			next.ServeHTTP(w, r.WithContext(WithPermissions(r.Context(), perms)))
+		})
+	}
+}
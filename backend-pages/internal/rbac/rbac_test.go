@@ -0,0 +1,95 @@
+package rbac
+
+import (
+	"pages/internal/models"
+	"testing"
+)
+
+func grant(role string, siteID, groupID int) models.RoleGrant {
+	g := models.RoleGrant{Role: role}
+	if siteID != 0 {
+		g.SiteID = &siteID
+	}
+	if groupID != 0 {
+		g.GroupID = &groupID
+	}
+	return g
+}
+
+func TestCan(t *testing.T) {
+	const (
+		site, otherSite = 1, 2
+		group, other    = 10, 11 // site 의 그룹
+		foreignGroup    = 20     // otherSite 의 그룹
+	)
+
+	tests := []struct {
+		name    string
+		grants  []models.RoleGrant
+		admin   bool
+		action  Action
+		siteID  int
+		groupID int
+		want    bool
+	}{
+		{"no grants", nil, false, ActionAccess, site, 0, false},
+		{"viewer views site", []models.RoleGrant{grant(RoleViewer, site, 0)}, false, ActionView, site, group, true},
+		{"viewer cannot edit", []models.RoleGrant{grant(RoleViewer, site, 0)}, false, ActionEdit, site, group, false},
+		{"editor cannot publish", []models.RoleGrant{grant(RoleEditor, site, 0)}, false, ActionPublish, site, group, false},
+		{"publisher publishes", []models.RoleGrant{grant(RolePublisher, site, 0)}, false, ActionPublish, site, group, true},
+		{"publisher cannot manage", []models.RoleGrant{grant(RolePublisher, site, 0)}, false, ActionManage, site, 0, false},
+		{"site-admin manages", []models.RoleGrant{grant(RoleSiteAdmin, site, 0)}, false, ActionManage, site, 0, true},
+		{"site-admin denied admin", []models.RoleGrant{grant(RoleSiteAdmin, site, 0)}, false, ActionAdmin, site, 0, false},
+		{"site-admin denied admin without site", []models.RoleGrant{grant(RoleSiteAdmin, site, 0)}, false, ActionAdmin, 0, 0, false},
+		{"site-admin of other site", []models.RoleGrant{grant(RoleSiteAdmin, otherSite, 0)}, false, ActionView, site, 0, false},
+		{"super-admin grant", []models.RoleGrant{grant(RoleSuperAdmin, 0, 0)}, false, ActionAdmin, 0, 0, true},
+		{"admin principal", nil, true, ActionAdmin, 0, 0, true},
+
+		// 그룹 역할은 그 그룹에서만 사이트 역할보다 높일 수 있습니다.
+		{"group editor edits group", []models.RoleGrant{grant(RoleEditor, site, group)}, false, ActionEdit, site, group, true},
+		{"group editor not other group", []models.RoleGrant{grant(RoleEditor, site, group)}, false, ActionEdit, site, other, false},
+		{"group editor not whole site", []models.RoleGrant{grant(RoleEditor, site, group)}, false, ActionEdit, site, 0, false},
+		{"group editor accesses site", []models.RoleGrant{grant(RoleEditor, site, group)}, false, ActionAccess, site, 0, true},
+		{"group editor not other site", []models.RoleGrant{grant(RoleEditor, site, group)}, false, ActionAccess, otherSite, 0, false},
+		{"group role raises site role", []models.RoleGrant{grant(RoleViewer, site, 0), grant(RolePublisher, site, group)}, false, ActionPublish, site, group, true},
+		{"group role does not raise other group", []models.RoleGrant{grant(RoleViewer, site, 0), grant(RolePublisher, site, group)}, false, ActionPublish, site, other, false},
+		{"lower group role keeps site role", []models.RoleGrant{grant(RolePublisher, site, 0), grant(RoleViewer, site, group)}, false, ActionPublish, site, group, true},
+		{"group role of other site ignored", []models.RoleGrant{grant(RolePublisher, otherSite, foreignGroup)}, false, ActionView, site, foreignGroup, false},
+		{"highest group grant wins", []models.RoleGrant{grant(RoleEditor, site, group), grant(RoleViewer, site, group)}, false, ActionEdit, site, group, true},
+		{"group publisher cannot manage", []models.RoleGrant{grant(RolePublisher, site, group)}, false, ActionManage, site, group, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(tt.grants, tt.admin)
+			if got := p.Can(tt.action, tt.siteID, tt.groupID); got != tt.want {
+				t.Errorf("Can(%s, %d, %d) = %t, want %t", tt.action, tt.siteID, tt.groupID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidScope(t *testing.T) {
+	tests := []struct {
+		role    string
+		siteID  int
+		groupID int
+		want    bool
+	}{
+		{RoleSuperAdmin, 0, 0, true},
+		{RoleSuperAdmin, 1, 0, false},
+		{RoleSiteAdmin, 1, 0, true},
+		{RoleSiteAdmin, 0, 0, false},
+		{RoleSiteAdmin, 1, 10, false},
+		{RoleEditor, 1, 0, true},
+		{RoleEditor, 1, 10, true},
+		{RoleEditor, 0, 0, false},
+		{"owner", 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidScope(tt.role, tt.siteID, tt.groupID); got != tt.want {
+			t.Errorf("ValidScope(%q, %d, %d) = %t, want %t", tt.role, tt.siteID, tt.groupID, got, tt.want)
+		}
+	}
+}
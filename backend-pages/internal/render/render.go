@@ -0,0 +1,269 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"pages/internal/content"
+	"pages/internal/models"
+	"pages/internal/pagetree"
+	"pages/internal/sitemap"
+	"pages/internal/store"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTheme 은 사이트에 테마가 없을 때 쓰는 기본 레이아웃입니다.
+//
+//go:embed themes/default.html
+var DefaultTheme string
+
+// PageData 는 테마 템플릿에 전달되는 값입니다.
+type PageData struct {
+	Site       *models.Site
+	Page       *models.Page  // NotFound 이면 nil
+	Content    template.HTML // Page 본문을 content_format 에 따라 렌더링하고 정제한 HTML
+	Menu       []MenuItem
+	Breadcrumb []models.Breadcrumb
+	NotFound   bool
+}
+
+// MenuItem 은 BuildMenuTree 결과에 링크 경로와 현재 위치 여부를 붙인 메뉴 항목입니다.
+type MenuItem struct {
+	PageID int
+	Title  string
+	Path   string
+	Active bool // 현재 페이지이거나 그 조상
+	Menu   []MenuItem
+}
+
+// ParseTheme 은 테마 템플릿을 파싱합니다.
+func ParseTheme(source string) (*template.Template, error) {
+	return template.New("theme").Parse(source)
+}
+
+// ValidateTheme 은 테마를 파싱한 뒤 예시 페이지와 404 페이지로 한 번씩 실행해 봅니다.
+// 없는 필드를 참조하는 등 실행 시점에만 드러나는 오류를 저장 전에 걸러냅니다.
+func ValidateTheme(source string) error {
+	tmpl, err := ParseTheme(source)
+	if err != nil {
+		return err
+	}
+
+	site := &models.Site{Code: "example", Name: "Example"}
+	page := &models.Page{PageID: 1, Title: "Home", Slug: "home", Content: "<p>Hello</p>"}
+	for _, pages := range [][]*models.Page{{page}, nil} {
+		data, _ := Build(site, pages, "/")
+		if data.Page != nil {
+			data.Content = template.HTML(content.ToHTML(data.Page.ContentFormat, data.Page.Content, content.Default))
+		}
+		if err := tmpl.Execute(io.Discard, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Server 는 Host 헤더를 사이트 도메인에, 요청 경로를 페이지 slug 경로에 대응시켜
+// 공개 중인 페이지를 사이트 테마로 렌더링합니다. /sitemap.xml 과 /robots.txt 도 제공합니다.
+type Server struct {
+	sites   store.SiteStore
+	pages   store.PageStore
+	content *content.Renderer
+
+	mu     sync.Mutex
+	themes map[int]cachedTheme
+}
+
+// contentCacheSize 는 캐시할 리비전 렌더링 결과의 최대 개수입니다.
+const contentCacheSize = 4096
+
+type cachedTheme struct {
+	source string
+	tmpl   *template.Template
+}
+
+func NewServer(sites store.SiteStore, pages store.PageStore) *Server {
+	return &Server{
+		sites:   sites,
+		pages:   pages,
+		content: content.NewRenderer(contentCacheSize),
+		themes:  make(map[int]cachedTheme),
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	site, err := s.sites.GetSiteByDomain(r.Context(), hostname(r.Host))
+	if errors.Is(err, store.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("render: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/sitemap.xml":
+		sitemap.Serve(w, r, s.pages, site)
+		return
+	case "/robots.txt":
+		sitemap.ServeRobots(w, r, s.sites, site)
+		return
+	}
+
+	pages, err := s.pages.ListSitePages(r.Context(), site.SiteID)
+	if err != nil {
+		log.Printf("render: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	pages = pagetree.Published(pages, time.Now())
+
+	body, status, err := s.Render(r.Context(), site, pages, r.URL.Path)
+	if err != nil {
+		log.Printf("render: site %d: %v", site.SiteID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// Render 는 공개 페이지 목록 pages 에서 path 를 찾아 사이트 테마로 렌더링한 HTML 과 HTTP 상태를 반환합니다.
+func (s *Server) Render(ctx context.Context, site *models.Site, pages []*models.Page, path string) ([]byte, int, error) {
+	data, status := Build(site, pages, path)
+	if data.Page != nil {
+		sanitizer, err := content.Load(ctx, s.sites, site.SiteID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("content policy: %w", err)
+		}
+		data.Content = template.HTML(s.content.Page(data.Page, sanitizer))
+	}
+
+	tmpl, err := s.theme(ctx, site.SiteID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("theme: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), status, nil
+}
+
+// Build 는 공개 페이지 목록에서 path 에 해당하는 페이지를 찾아 템플릿 데이터와 HTTP 상태를 만듭니다.
+// "/" 는 첫 번째 최상위 페이지로 처리합니다. 메뉴는 대상 페이지가 속한 그룹의 트리입니다.
+// Content 는 호출하는 쪽에서 채웁니다.
+func Build(site *models.Site, pages []*models.Page, path string) (PageData, int) {
+	var trail []*models.Page
+	found := false
+	if len(pagetree.Segments(path)) == 0 {
+		if home := firstRoot(pages); home != nil {
+			trail, found = []*models.Page{home}, true
+		}
+	} else {
+		trail, found = pagetree.Resolve(pages, path)
+	}
+
+	data := PageData{Site: site, Breadcrumb: pagetree.Breadcrumbs(trail)}
+	status := http.StatusOK
+
+	var groupPage *models.Page
+	if found {
+		data.Page = trail[len(trail)-1]
+		groupPage = data.Page
+	} else {
+		data.NotFound = true
+		status = http.StatusNotFound
+		groupPage = firstRoot(pages)
+	}
+
+	if groupPage != nil {
+		active := make(map[int]bool, len(trail))
+		for _, p := range trail {
+			active[p.PageID] = true
+		}
+		var groupPages []*models.Page
+		for _, p := range pages {
+			if p.GroupID == groupPage.GroupID {
+				groupPages = append(groupPages, p)
+			}
+		}
+		data.Menu = menuItems(pagetree.BuildMenuTree(groupPages), "", active)
+	}
+
+	return data, status
+}
+
+func menuItems(pages []*models.Page, parentPath string, active map[int]bool) []MenuItem {
+	items := make([]MenuItem, 0, len(pages))
+	for _, p := range pages {
+		path := parentPath + "/" + p.Slug
+		items = append(items, MenuItem{
+			PageID: p.PageID,
+			Title:  p.Title,
+			Path:   path,
+			Active: active[p.PageID],
+			Menu:   menuItems(p.Menu, path, active),
+		})
+	}
+	return items
+}
+
+func firstRoot(pages []*models.Page) *models.Page {
+	for _, p := range pages {
+		if p.ParentID == nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// theme 은 사이트 테마를 파싱해 캐시하고, 테마가 없으면 기본 테마를 반환합니다.
+func (s *Server) theme(ctx context.Context, siteID int) (*template.Template, error) {
+	source := DefaultTheme
+	theme, err := s.sites.GetSiteTheme(ctx, siteID)
+	if err == nil {
+		source = theme.Template
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cached, ok := s.themes[siteID]; ok && cached.source == source {
+		return cached.tmpl, nil
+	}
+	tmpl, err := ParseTheme(source)
+	if err != nil {
+		return nil, err
+	}
+	s.themes[siteID] = cachedTheme{source: source, tmpl: tmpl}
+	return tmpl, nil
+}
+
+// hostname 은 Host 헤더에서 포트를 떼고 소문자로 바꿉니다.
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
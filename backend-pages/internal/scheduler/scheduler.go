@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Store 는 예약 공개/만료를 처리하는 저장소입니다.
+// 각 메서드는 조건 확인과 갱신을 한 번에 수행해야 여러 인스턴스에서 동시에 실행해도 안전합니다.
+type Store interface {
+	PublishScheduled(ctx context.Context, now time.Time) (int64, error)
+	UnpublishExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Scheduler 는 interval 마다 publish_at, unpublish_at 이 지난 페이지의 공개 상태를 바꿉니다.
+type Scheduler struct {
+	store    Store
+	interval time.Duration
+}
+
+func New(store Store, interval time.Duration) *Scheduler {
+	return &Scheduler{store: store, interval: interval}
+}
+
+// Run 은 ctx 가 취소될 때까지 주기적으로 예약 작업을 처리합니다.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.RunOnce(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 는 now 기준으로 예약 공개를 먼저, 만료를 나중에 처리합니다.
+// 공개와 만료 시각이 모두 지난 페이지는 결과적으로 비공개가 됩니다.
+func (s *Scheduler) RunOnce(ctx context.Context, now time.Time) {
+	published, err := s.store.PublishScheduled(ctx, now)
+	if err != nil {
+		log.Printf("scheduler: publish failed: %v", err)
+	} else if published > 0 {
+		log.Printf("scheduler: published %d page(s)", published)
+	}
+
+	unpublished, err := s.store.UnpublishExpired(ctx, now)
+	if err != nil {
+		log.Printf("scheduler: unpublish failed: %v", err)
+	} else if unpublished > 0 {
+		log.Printf("scheduler: unpublished %d page(s)", unpublished)
+	}
+}
@@ -0,0 +1,240 @@
+package search
+
+import (
+	"context"
+	"math"
+	"pages/internal/content"
+	"pages/internal/models"
+	"pages/internal/store"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// titleWeight 는 제목에서 일치한 단어의 가중치입니다.
+const titleWeight = 3
+
+// Index 는 FULLTEXT 인덱스가 없는 SQLite 에서 쓰는 프로세스 내 역색인입니다.
+// 리비전 내용은 바뀌지 않으므로 문서를 (페이지, 리비전) 단위로 색인하고, 검색할 때마다 현재 페이지 목록을 읽어
+// 아직 색인하지 않은 리비전을 추가하고 더 이상 쓰이지 않는 리비전을 지웁니다.
+type Index struct {
+	sites store.SiteStore
+	pages store.PageStore
+
+	mu    sync.Mutex
+	docs  map[docKey]*document
+	terms map[string]map[docKey]posting
+}
+
+type docKey struct {
+	pageID   int
+	revision int
+}
+
+type document struct {
+	siteID int
+	words  []string // terms 에서 지울 때 쓰는 단어 목록
+}
+
+// posting 은 문서 안에서 단어가 나온 횟수입니다.
+type posting struct {
+	title   int
+	content int
+}
+
+var _ Backend = (*Index)(nil)
+
+func NewIndex(sites store.SiteStore, pages store.PageStore) *Index {
+	return &Index{
+		sites: sites,
+		pages: pages,
+		docs:  make(map[docKey]*document),
+		terms: make(map[string]map[docKey]posting),
+	}
+}
+
+func (x *Index) SearchPages(ctx context.Context, q models.SearchQuery) ([]models.SearchHit, int, error) {
+	pages, siteIDs, err := x.load(ctx, q.SiteID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 검색 대상 문서: 공개 필터가 true 이면 공개 스냅샷, 그 밖에는 초안
+	now := time.Now()
+	keep := make(map[docKey]bool)
+	candidates := make(map[docKey]*models.Page)
+	for _, p := range pages {
+		keep[docKey{p.PageID, p.Revision}] = true
+		if p.Published != nil {
+			keep[docKey{p.PageID, p.Published.Revision}] = true
+		}
+
+		if q.GroupID != 0 && p.GroupID != q.GroupID {
+			continue
+		}
+		doc := p
+		if q.Published != nil {
+			live := p.IsLive(now)
+			if *q.Published != live {
+				continue
+			}
+			if live {
+				doc = p.PublishedView(now)
+			}
+		}
+		candidates[docKey{doc.PageID, doc.Revision}] = doc
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.prune(siteIDs, keep)
+	for key, page := range candidates {
+		if _, ok := x.docs[key]; !ok {
+			x.add(key, page)
+		}
+	}
+
+	scores := make(map[docKey]float64)
+	matched := make(map[docKey]int)
+	for _, term := range q.Terms {
+		perDoc := make(map[docKey]posting)
+		for word, postings := range x.terms {
+			if !strings.HasPrefix(word, term) {
+				continue
+			}
+			for key, p := range postings {
+				if _, ok := candidates[key]; ok {
+					acc := perDoc[key]
+					acc.title += p.title
+					acc.content += p.content
+					perDoc[key] = acc
+				}
+			}
+		}
+		if len(perDoc) == 0 {
+			return []models.SearchHit{}, 0, nil
+		}
+		idf := math.Log(1 + float64(len(candidates))/float64(len(perDoc)))
+		for key, p := range perDoc {
+			scores[key] += idf * (titleWeight*tf(p.title) + tf(p.content))
+			matched[key]++
+		}
+	}
+
+	// 모든 검색어가 일치한 문서만 점수 순으로
+	var keys []docKey
+	for key, n := range matched {
+		if n == len(q.Terms) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] != scores[keys[j]] {
+			return scores[keys[i]] > scores[keys[j]]
+		}
+		return keys[i].pageID < keys[j].pageID
+	})
+
+	total := len(keys)
+	if q.Offset >= len(keys) {
+		keys = nil
+	} else {
+		keys = keys[q.Offset:]
+	}
+	if q.Limit > 0 && len(keys) > q.Limit {
+		keys = keys[:q.Limit]
+	}
+
+	hits := make([]models.SearchHit, 0, len(keys))
+	for _, key := range keys {
+		page := candidates[key]
+		hits = append(hits, models.SearchHit{
+			PageID:        page.PageID,
+			SiteID:        page.SiteID,
+			GroupID:       page.GroupID,
+			Slug:          page.Slug,
+			IsPublished:   page.IsPublished,
+			Title:         page.Title,
+			Score:         math.Round(scores[key]*1000) / 1000,
+			Content:       page.Content,
+			ContentFormat: page.ContentFormat,
+		})
+	}
+	return hits, total, nil
+}
+
+// load 는 siteID 사이트(0 이면 모든 사이트)의 페이지와 대상 사이트 ID 목록을 읽습니다.
+func (x *Index) load(ctx context.Context, siteID int) ([]*models.Page, map[int]bool, error) {
+	siteIDs := make(map[int]bool)
+	if siteID != 0 {
+		siteIDs[siteID] = true
+	} else {
+		sites, err := x.sites.ListSites(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, site := range sites {
+			siteIDs[site.SiteID] = true
+		}
+	}
+
+	var pages []*models.Page
+	for id := range siteIDs {
+		sitePages, err := x.pages.ListSitePages(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		pages = append(pages, sitePages...)
+	}
+	return pages, siteIDs, nil
+}
+
+// add 는 page 의 제목과 본문 텍스트를 key 문서로 색인합니다.
+func (x *Index) add(key docKey, page *models.Page) {
+	counts := make(map[string]posting)
+	for _, w := range words(page.Title) {
+		p := counts[w]
+		p.title++
+		counts[w] = p
+	}
+	for _, w := range words(content.Text(page.ContentFormat, page.Content)) {
+		p := counts[w]
+		p.content++
+		counts[w] = p
+	}
+
+	doc := &document{siteID: page.SiteID}
+	for w, p := range counts {
+		if x.terms[w] == nil {
+			x.terms[w] = make(map[docKey]posting)
+		}
+		x.terms[w][key] = p
+		doc.words = append(doc.words, w)
+	}
+	x.docs[key] = doc
+}
+
+// prune 은 siteIDs 사이트의 문서 중 keep 에 없는(삭제되었거나 초안, 공개 스냅샷 어느 쪽도 아닌) 리비전을 지웁니다.
+func (x *Index) prune(siteIDs map[int]bool, keep map[docKey]bool) {
+	for key, doc := range x.docs {
+		if !siteIDs[doc.siteID] || keep[key] {
+			continue
+		}
+		for _, w := range doc.words {
+			delete(x.terms[w], key)
+			if len(x.terms[w]) == 0 {
+				delete(x.terms, w)
+			}
+		}
+		delete(x.docs, key)
+	}
+}
+
+func tf(n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return 1 + math.Log(float64(n))
+}
@@ -0,0 +1,142 @@
+package search
+
+import (
+	"context"
+	"html"
+	"pages/internal/content"
+	"pages/internal/models"
+	"strings"
+	"unicode"
+)
+
+// snippetLength 는 본문 발췌의 최대 글자 수입니다.
+const snippetLength = 200
+
+// Backend 는 페이지 검색 저장소입니다. 반환하는 SearchHit 의 Title, Content 는 강조 전 원문이며
+// 두 번째 반환값은 Limit, Offset 적용 전 전체 건수입니다.
+// MySQL 은 FULLTEXT 인덱스를 쓰는 store.SQLStore 가, SQLite 는 프로세스 내 Index 가 구현합니다.
+// 두 구현 모두 페이지 원문(사이트 기본 언어)만 검색하며 번역은 검색하지 않습니다.
+type Backend interface {
+	SearchPages(ctx context.Context, q models.SearchQuery) ([]models.SearchHit, int, error)
+}
+
+// Service 는 검색어를 나누어 Backend 에 넘기고, 결과에 강조한 제목과 본문 발췌를 붙입니다.
+type Service struct {
+	backend Backend
+}
+
+func New(backend Backend) *Service {
+	return &Service{backend: backend}
+}
+
+func (s *Service) Search(ctx context.Context, q models.SearchQuery) (*models.SearchResult, error) {
+	result := &models.SearchResult{Query: q.Text, Hits: []models.SearchHit{}}
+	q.Terms = Tokenize(q.Text)
+	if len(q.Terms) == 0 {
+		return result, nil
+	}
+
+	hits, total, err := s.backend.SearchPages(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	for i := range hits {
+		hits[i].Title = Highlight(hits[i].Title, q.Terms)
+		hits[i].Snippet = Snippet(content.Text(hits[i].ContentFormat, hits[i].Content), q.Terms)
+	}
+	result.Total = total
+	result.Hits = hits
+	return result, nil
+}
+
+// words 는 text 를 소문자 단어(문자와 숫자의 연속) 목록으로 나눕니다.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Tokenize 는 검색어를 중복 없는 소문자 단어 목록으로 나눕니다.
+func Tokenize(text string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, w := range words(text) {
+		if !seen[w] {
+			seen[w] = true
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// matches 는 word 가 terms 중 하나로 시작하는지 반환합니다. "페이지를" 처럼 조사가 붙은 단어도 찾기 위해 접두어로 비교합니다.
+func matches(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlight 는 text 를 HTML 이스케이프하고 검색어로 시작하는 단어를 <mark> 로 감쌉니다.
+func Highlight(text string, terms []string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsNumber(runes[j])) {
+			j++
+		}
+		if j == i {
+			b.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+		word := string(runes[i:j])
+		if matches(word, terms) {
+			b.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// Snippet 은 text 에서 처음 일치한 단어 주변을 snippetLength 글자 이내로 잘라 강조합니다.
+func Snippet(text string, terms []string) string {
+	runes := []rune(text)
+	start := 0
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsNumber(runes[j])) {
+			j++
+		}
+		if j > i && matches(string(runes[i:j]), terms) {
+			start = i - snippetLength/4
+			break
+		}
+		if j == i {
+			j++
+		}
+		i = j
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	snippet := Highlight(string(runes[start:end]), terms)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
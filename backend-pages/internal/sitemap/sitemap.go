@@ -0,0 +1,206 @@
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"pages/internal/models"
+	"pages/internal/pagetree"
+	"pages/internal/store"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// MaxURLs 는 sitemap 파일 하나에 넣을 수 있는 최대 URL 수입니다. 넘으면 sitemap index 로 나눕니다.
+const MaxURLs = 50000
+
+// URL 은 sitemap 의 <url> 항목입니다.
+type URL struct {
+	Loc      string `xml:"loc"`
+	LastMod  string `xml:"lastmod,omitempty"`
+	Priority string `xml:"priority,omitempty"`
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []URL    `xml:"url"`
+}
+
+type indexEntry struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []indexEntry `xml:"sitemap"`
+}
+
+// BaseURL 은 사이트 공개 주소(https://<domain>)입니다. 도메인이 없으면 빈 문자열입니다.
+func BaseURL(site *models.Site) string {
+	if site.Domain == nil || *site.Domain == "" {
+		return ""
+	}
+	return "https://" + *site.Domain
+}
+
+// PageURLs 는 공개 페이지 목록으로 baseURL 기준의 sitemap 항목을 만듭니다.
+// 사이트 루트("/")와 트리의 각 페이지 경로가 포함되며, lastmod 는 공개 시각(published_at),
+// priority 는 루트 1.0 에서 Depth 가 깊어질수록 0.2 씩 낮아집니다(최소 0.1).
+func PageURLs(baseURL string, pages []*models.Page) []URL {
+	baseURL = strings.TrimRight(baseURL, "/")
+	roots := pagetree.BuildMenuTree(pages)
+
+	urls := []URL{}
+	if len(roots) > 0 {
+		urls = append(urls, URL{Loc: baseURL + "/", LastMod: lastMod(roots[0]), Priority: "1.0"})
+	}
+	pagetree.Walk(roots, func(page *models.Page, path string) {
+		urls = append(urls, URL{Loc: baseURL + path, LastMod: lastMod(page), Priority: priority(page.Depth)})
+	})
+	return urls
+}
+
+// lastMod 는 공개 스냅샷을 공개한 시각입니다. 초안 수정(updated_at)은 공개 내용을 바꾸지 않으므로 쓰지 않습니다.
+func lastMod(page *models.Page) string {
+	if page.PublishedAt == nil || page.PublishedAt.IsZero() {
+		return ""
+	}
+	return page.PublishedAt.UTC().Format(time.RFC3339)
+}
+
+func priority(depth int) string {
+	p := 8 - 2*depth
+	if p < 1 {
+		p = 1
+	}
+	return fmt.Sprintf("0.%d", p)
+}
+
+// Split 은 urls 를 MaxURLs 개씩 나눕니다. urls 가 비어 있어도 빈 조각 하나를 반환합니다.
+func Split(urls []URL) [][]URL {
+	chunks := [][]URL{}
+	for len(urls) > MaxURLs {
+		chunks = append(chunks, urls[:MaxURLs])
+		urls = urls[MaxURLs:]
+	}
+	return append(chunks, urls)
+}
+
+// Write 는 urls 로 sitemap.xml 문서를 씁니다.
+func Write(w io.Writer, urls []URL) error {
+	return encode(w, urlSet{Xmlns: xmlns, URLs: urls})
+}
+
+// WriteIndex 는 locs 의 sitemap 파일을 가리키는 sitemap index 문서를 씁니다.
+func WriteIndex(w io.Writer, locs []string) error {
+	index := sitemapIndex{Xmlns: xmlns}
+	for _, loc := range locs {
+		index.Sitemaps = append(index.Sitemaps, indexEntry{Loc: loc})
+	}
+	return encode(w, index)
+}
+
+func encode(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Robots 는 사이트에 robots.txt 가 저장되어 있지 않을 때 쓰는 기본 내용입니다.
+func Robots(baseURL string) string {
+	robots := "User-agent: *\nAllow: /\n"
+	if baseURL != "" {
+		robots += "\nSitemap: " + strings.TrimRight(baseURL, "/") + "/sitemap.xml\n"
+	}
+	return robots
+}
+
+// Serve 는 site 의 sitemap.xml 을 응답합니다. URL 이 MaxURLs 를 넘으면 page 쿼리가 없는 요청에는
+// <baseURL>/sitemap.xml?page=N 을 가리키는 index 를, page=N 요청에는 N 번째 조각을 보냅니다.
+// API 와 공개 호스트가 같은 문서를 내보내도록 index 의 주소는 항상 사이트 공개 주소입니다.
+func Serve(w http.ResponseWriter, r *http.Request, pages store.PageStore, site *models.Site) {
+	baseURL := BaseURL(site)
+	if baseURL == "" {
+		http.Error(w, "사이트 도메인이 없어 sitemap 을 만들 수 없습니다", http.StatusNotFound)
+		return
+	}
+
+	page := 0
+	if value := r.URL.Query().Get("page"); value != "" {
+		var err error
+		if page, err = strconv.Atoi(value); err != nil || page < 1 {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			return
+		}
+	}
+
+	urls, err := siteURLs(r.Context(), pages, site, baseURL)
+	if err != nil {
+		log.Printf("sitemap: site %d: %v", site.SiteID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	chunks := Split(urls)
+	if page > len(chunks) || (page > 0 && len(chunks) == 1) {
+		http.Error(w, "Sitemap page not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	switch {
+	case len(chunks) == 1:
+		err = Write(w, chunks[0])
+	case page == 0:
+		locs := make([]string, len(chunks))
+		for i := range chunks {
+			locs[i] = fmt.Sprintf("%s/sitemap.xml?page=%d", baseURL, i+1)
+		}
+		err = WriteIndex(w, locs)
+	default:
+		err = Write(w, chunks[page-1])
+	}
+	if err != nil {
+		log.Printf("sitemap: site %d: %v", site.SiteID, err)
+	}
+}
+
+func siteURLs(ctx context.Context, pages store.PageStore, site *models.Site, baseURL string) ([]URL, error) {
+	all, err := pages.ListSitePages(ctx, site.SiteID)
+	if err != nil {
+		return nil, err
+	}
+	return PageURLs(baseURL, pagetree.Published(all, time.Now())), nil
+}
+
+// ServeRobots 는 사이트의 robots.txt 를 응답합니다. 저장된 내용이 없으면 Robots 기본값을 보냅니다.
+func ServeRobots(w http.ResponseWriter, r *http.Request, sites store.SiteStore, site *models.Site) {
+	content := Robots(BaseURL(site))
+	robots, err := sites.GetSiteRobots(r.Context(), site.SiteID)
+	if err == nil {
+		content = robots.Content
+	} else if !errors.Is(err, store.ErrNotFound) {
+		log.Printf("robots: site %d: %v", site.SiteID, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, content)
+}
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pages/internal/models"
+)
+
+const grantColumns = "r.grant_id, r.user_id, r.role, r.site_id, s.code, r.group_id, r.created_at"
+
+// grantFrom 은 역할과 그 사이트 코드를 읽는 FROM 절입니다. super-admin 은 사이트가 없으므로 LEFT JOIN 입니다.
+const grantFrom = " FROM role_grants r LEFT JOIN sites s ON s.site_id = r.site_id"
+
+func scanGrants(rows *sql.Rows) ([]models.RoleGrant, error) {
+	defer rows.Close()
+
+	grants := []models.RoleGrant{}
+	for rows.Next() {
+		var g models.RoleGrant
+		if err := rows.Scan(&g.GrantID, &g.UserID, &g.Role, &g.SiteID, &g.SiteCode, &g.GroupID, &g.CreatedAt); err != nil {
+			return nil, err
+		}
+		grants = append(grants, g)
+	}
+	return grants, rows.Err()
+}
+
+func (s *SQLStore) ListUsers(ctx context.Context) ([]models.User, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, "SELECT user_id, subject, name, created_at FROM users ORDER BY user_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.UserID, &u.Subject, &u.Name, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (s *SQLStore) GetUser(ctx context.Context, userID int) (*models.User, error) {
+	var u models.User
+	err := s.conn(ctx).QueryRowContext(ctx,
+		"SELECT user_id, subject, name, created_at FROM users WHERE user_id = ?",
+		userID,
+	).Scan(&u.UserID, &u.Subject, &u.Name, &u.CreatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	rows, err := s.conn(ctx).QueryContext(ctx, "SELECT "+grantColumns+grantFrom+" WHERE r.user_id = ? ORDER BY r.grant_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.Grants, err = scanGrants(rows); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (s *SQLStore) CreateUser(ctx context.Context, input models.CreateUserInput) (int64, error) {
+	result, err := s.conn(ctx).ExecContext(ctx,
+		"INSERT INTO users (subject, name) VALUES (?, ?)",
+		input.Subject, input.Name,
+	)
+	if err != nil {
+		return 0, conflict(err, ErrConflict)
+	}
+	return result.LastInsertId()
+}
+
+func (s *SQLStore) DeleteUser(ctx context.Context, userID int) error {
+	result, err := s.conn(ctx).ExecContext(ctx, "DELETE FROM users WHERE user_id = ?", userID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
+
+func (s *SQLStore) ListGrantsBySubject(ctx context.Context, subject string) ([]models.RoleGrant, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx,
+		"SELECT "+grantColumns+grantFrom+" JOIN users u ON u.user_id = r.user_id WHERE u.subject = ? ORDER BY r.grant_id",
+		subject,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanGrants(rows)
+}
+
+// CreateGrant 는 site_id, group_id 가 NULL 일 수 있어 UNIQUE 제약 대신 같은 역할이 있는지 직접 확인합니다.
+func (s *SQLStore) CreateGrant(ctx context.Context, userID int, role string, siteID, groupID *int) (int64, error) {
+	var id int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists int
+		err := tx.QueryRowContext(ctx, "SELECT 1 FROM users WHERE user_id = ?", userID).Scan(&exists)
+		if err != nil {
+			return notFound(err)
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			SELECT 1 FROM role_grants
+			WHERE user_id = ? AND role = ?
+				AND (site_id = ? OR (site_id IS NULL AND ? IS NULL))
+				AND (group_id = ? OR (group_id IS NULL AND ? IS NULL))`,
+			userID, role, siteID, siteID, groupID, groupID,
+		).Scan(&exists)
+		switch {
+		case err == nil:
+			return ErrConflict
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx,
+			"INSERT INTO role_grants (user_id, role, site_id, group_id) VALUES (?, ?, ?, ?)",
+			userID, role, siteID, groupID,
+		)
+		if err != nil {
+			return err
+		}
+		id, err = result.LastInsertId()
+		return err
+	})
+	return id, err
+}
+
+func (s *SQLStore) DeleteGrant(ctx context.Context, userID, grantID int) error {
+	result, err := s.conn(ctx).ExecContext(ctx, "DELETE FROM role_grants WHERE grant_id = ? AND user_id = ?", grantID, userID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
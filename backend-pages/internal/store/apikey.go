@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"pages/internal/models"
+)
+
+const apiKeyColumns = "key_id, name, prefix, is_admin, expires_at, last_used_at, revoked_at, created_at"
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := row.Scan(&key.KeyID, &key.Name, &key.Prefix, &key.Admin, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (s *SQLStore) CreateAPIKey(ctx context.Context, key *models.APIKey, hash string) (int64, error) {
+	var expiresAt interface{}
+	if key.ExpiresAt != nil {
+		expiresAt = s.timeArg(*key.ExpiresAt)
+	}
+	result, err := s.conn(ctx).ExecContext(ctx,
+		"INSERT INTO api_keys (name, prefix, key_hash, is_admin, expires_at) VALUES (?, ?, ?, ?, ?)",
+		key.Name, key.Prefix, hash, key.Admin, expiresAt,
+	)
+	if err != nil {
+		return 0, conflict(err, ErrConflict)
+	}
+	return result.LastInsertId()
+}
+
+func (s *SQLStore) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY key_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *SQLStore) GetAPIKey(ctx context.Context, keyID int) (*models.APIKey, error) {
+	key, err := scanAPIKey(s.conn(ctx).QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_id = ?", keyID))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return key, nil
+}
+
+func (s *SQLStore) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	key, err := scanAPIKey(s.conn(ctx).QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = ?", hash))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return key, nil
+}
+
+func (s *SQLStore) RevokeAPIKey(ctx context.Context, keyID int) error {
+	result, err := s.conn(ctx).ExecContext(ctx,
+		"UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE key_id = ? AND revoked_at IS NULL",
+		keyID,
+	)
+	if err != nil {
+		return err
+	}
+	if expectRows(result) == nil {
+		return nil
+	}
+	// 바뀐 행이 없으면 이미 폐기한 키인지 확인합니다.
+	_, err = s.GetAPIKey(ctx, keyID)
+	return err
+}
+
+func (s *SQLStore) TouchAPIKey(ctx context.Context, keyID int) error {
+	_, err := s.conn(ctx).ExecContext(ctx, "UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE key_id = ?", keyID)
+	return err
+}
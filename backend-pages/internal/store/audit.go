@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"pages/internal/listquery"
+	"pages/internal/models"
+)
+
+const auditColumns = "e.event_id, e.actor, e.actor_name, e.site_id, e.entity_type, e.entity_id, e.action, e.before_data, e.after_data, e.created_at"
+
+// snapshot 은 JSON 스냅샷을 저장할 값으로 바꿉니다. 없으면 NULL 입니다.
+func snapshot(data json.RawMessage) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+func (s *SQLStore) RecordAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	return insertAuditEvent(ctx, s.conn(ctx), event)
+}
+
+// execer 는 *sql.DB 와 *sql.Tx 의 공통 실행 메서드입니다.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertAuditEvent 는 이력을 q 로 기록합니다. 변경과 같은 트랜잭션에서 기록할 때 tx 를 넘깁니다.
+func insertAuditEvent(ctx context.Context, q execer, event *models.AuditEvent) error {
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO audit_events (actor, actor_name, site_id, entity_type, entity_id, action, before_data, after_data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.Actor, event.ActorName, event.SiteID, event.EntityType, event.EntityID, event.Action,
+		snapshot(event.Before), snapshot(event.After),
+	)
+	return err
+}
+
+func (s *SQLStore) QueryAuditEvents(ctx context.Context, siteID int, q *listquery.Query) ([]models.AuditEvent, string, error) {
+	if err := s.checkCursor(ctx, q); err != nil {
+		return nil, "", err
+	}
+	where := "1 = 1"
+	var args []interface{}
+	if siteID != 0 {
+		where, args = "e.site_id = ?", []interface{}{siteID}
+	}
+	query, args := q.SQL("SELECT "+auditColumns+" FROM audit_events e WHERE "+where, args, s.timeArg)
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	events := []models.AuditEvent{}
+	for rows.Next() {
+		var e models.AuditEvent
+		var before, after sql.NullString
+		if err := rows.Scan(&e.EventID, &e.Actor, &e.ActorName, &e.SiteID, &e.EntityType, &e.EntityID, &e.Action, &before, &after, &e.CreatedAt); err != nil {
+			return nil, "", err
+		}
+		if before.Valid {
+			e.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			e.After = json.RawMessage(after.String)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	n, next := q.Page(len(events), func(i int) int64 { return int64(events[i].EventID) })
+	return events[:n], next, nil
+}
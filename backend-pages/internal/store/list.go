@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pages/internal/listquery"
+	"pages/internal/models"
+	"time"
+)
+
+// updatedExpr 는 수정된 적 없는 행을 생성 시각으로 정렬, 필터하는 식입니다.
+const updatedExpr = "COALESCE(%[1]s.updated_at, %[1]s.created_at)"
+
+// SiteList 는 사이트 목록(QuerySites)의 정렬, 필터 정의입니다.
+var SiteList = listquery.Spec{
+	Table: "sites",
+	Alias: "s",
+	Key:   "site_id",
+	Sorts: []listquery.Field{
+		{Name: "code"},
+		{Name: "name"},
+		{Name: "created_at"},
+		{Name: "updated_at", Expr: updatedExpr},
+	},
+	Filters: []listquery.Field{
+		{Name: "name", Kind: listquery.Contains},
+		{Name: "updated_since", Expr: updatedExpr, Kind: listquery.Since},
+	},
+}
+
+// PageGroupList 는 페이지 그룹 목록(QueryPageGroups)의 정렬, 필터 정의입니다.
+var PageGroupList = listquery.Spec{
+	Table: "page_groups",
+	Alias: "g",
+	Key:   "group_id",
+	Sorts: []listquery.Field{
+		{Name: "name"},
+		{Name: "created_at"},
+		{Name: "updated_at", Expr: updatedExpr},
+	},
+	DefaultSort: "name",
+	Filters: []listquery.Field{
+		{Name: "name", Kind: listquery.Contains},
+		{Name: "updated_since", Expr: updatedExpr, Kind: listquery.Since},
+	},
+}
+
+// PageList 는 그룹 페이지 목록(QueryPages)의 정렬, 필터 정의입니다.
+var PageList = listquery.Spec{
+	Table: "pages",
+	Alias: "p",
+	Key:   "page_id",
+	Sorts: []listquery.Field{
+		{Name: "title"},
+		{Name: "slug"},
+		{Name: "depth"},
+		{Name: "menu_order"},
+		{Name: "created_at"},
+		{Name: "updated_at", Expr: updatedExpr},
+	},
+	DefaultSort: "depth,menu_order",
+	Filters: []listquery.Field{
+		{Name: "is_published", Kind: listquery.Bool},
+		{Name: "parent_id", Kind: listquery.NullableInt},
+		{Name: "depth", Kind: listquery.Int},
+		{Name: "updated_since", Expr: updatedExpr, Kind: listquery.Since},
+		{Name: "title", Kind: listquery.Contains},
+	},
+}
+
+// timeArg 는 t 를 created_at, updated_at 과 비교할 수 있는 인자로 바꿉니다.
+// SQLite 는 CURRENT_TIMESTAMP 를 "YYYY-MM-DD HH:MM:SS"(UTC) 문자열로 저장하므로 같은 형식으로 맞춥니다.
+func (s *SQLStore) timeArg(t time.Time) interface{} {
+	if s.sqlite {
+		return t.UTC().Format(time.DateTime)
+	}
+	return t
+}
+
+// checkCursor 는 q 의 커서 앵커 행이 있는지 확인합니다. 없으면 listquery.ErrCursor 를 반환합니다.
+func (s *SQLStore) checkCursor(ctx context.Context, q *listquery.Query) error {
+	if q.After == 0 {
+		return nil
+	}
+	var found int
+	err := s.conn(ctx).QueryRowContext(ctx, q.AnchorSQL(), q.After).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return listquery.ErrCursor
+	}
+	return err
+}
+
+func (s *SQLStore) QuerySites(ctx context.Context, q *listquery.Query) ([]models.Site, string, error) {
+	if err := s.checkCursor(ctx, q); err != nil {
+		return nil, "", err
+	}
+	query, args := q.SQL("SELECT "+siteColumns+" FROM sites s WHERE 1 = 1", nil, s.timeArg)
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	sites := []models.Site{}
+	for rows.Next() {
+		site, err := scanSite(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		sites = append(sites, *site)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	n, next := q.Page(len(sites), func(i int) int64 { return int64(sites[i].SiteID) })
+	return sites[:n], next, nil
+}
+
+func (s *SQLStore) QueryPageGroups(ctx context.Context, siteID int, q *listquery.Query) ([]models.PageGroup, string, error) {
+	if err := s.checkCursor(ctx, q); err != nil {
+		return nil, "", err
+	}
+	query, args := q.SQL("SELECT "+groupColumns+" FROM page_groups g WHERE g.site_id = ?", []interface{}{siteID}, s.timeArg)
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	groups := []models.PageGroup{}
+	for rows.Next() {
+		group, err := scanPageGroup(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		groups = append(groups, *group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	n, next := q.Page(len(groups), func(i int) int64 { return int64(groups[i].GroupID) })
+	return groups[:n], next, nil
+}
+
+func (s *SQLStore) QueryPages(ctx context.Context, siteID, groupID int, q *listquery.Query) ([]*models.Page, string, error) {
+	if err := s.checkCursor(ctx, q); err != nil {
+		return nil, "", err
+	}
+	query, args := q.SQL(
+		"SELECT "+pageColumns+" FROM "+pageTables+" WHERE p.site_id = ? AND p.group_id = ?",
+		[]interface{}{siteID, groupID}, s.timeArg,
+	)
+	pages, err := s.queryPages(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	n, next := q.Page(len(pages), func(i int) int64 { return int64(pages[i].PageID) })
+	return pages[:n], next, nil
+}
+
+// auditFilters 는 변경 이력 목록의 필터입니다. since, until 로 기간을 정합니다.
+var auditFilters = []listquery.Field{
+	{Name: "entity_type", Kind: listquery.String},
+	{Name: "entity_id", Kind: listquery.Int},
+	{Name: "action", Kind: listquery.String},
+	{Name: "actor", Kind: listquery.String},
+	{Name: "since", Expr: "%[1]s.created_at", Kind: listquery.Since},
+	{Name: "until", Expr: "%[1]s.created_at", Kind: listquery.Until},
+}
+
+// AuditList 는 사이트 변경 이력 목록(QueryAuditEvents)의 정렬, 필터 정의입니다. 기본은 최신순입니다.
+var AuditList = listquery.Spec{
+	Table:       "audit_events",
+	Alias:       "e",
+	Key:         "event_id",
+	Sorts:       []listquery.Field{{Name: "event_id"}},
+	DefaultSort: "-event_id",
+	Filters:     auditFilters,
+}
+
+// AdminAuditList 는 AuditList 에 site_id 필터를 더한 전체 변경 이력 목록의 정의입니다.
+var AdminAuditList = listquery.Spec{
+	Table:       "audit_events",
+	Alias:       "e",
+	Key:         "event_id",
+	Sorts:       []listquery.Field{{Name: "event_id"}},
+	DefaultSort: "-event_id",
+	Filters:     append([]listquery.Field{{Name: "site_id", Kind: listquery.Int}}, auditFilters...),
+}
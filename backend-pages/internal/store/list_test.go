@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"net/url"
+	"pages/internal/listquery"
+	"pages/internal/models"
+	"sort"
+	"testing"
+)
+
+// 커서로 이어 받은 목록은 겹치거나 빠지는 행 없이 한 번에 받은 정렬 순서와 같아야 합니다.
+// 값이 같은 행은 page_id 오름차순으로 이어집니다.
+func TestQueryPagesCursor(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	siteID, groupID := testGroup(t, s)
+
+	// 제목이 겹치는 최상위 페이지 6개와 그 하위 페이지 3개
+	titles := []string{"b", "a", "b", "c", "a", "b"}
+	var roots []int
+	for i, title := range titles {
+		page := &models.Page{SiteID: siteID, GroupID: groupID, Title: title, Slug: "root-" + string(rune('0'+i)), ContentFormat: "markdown"}
+		id, err := s.CreatePage(ctx, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, int(id))
+	}
+	for i, parent := range []int{roots[0], roots[0], roots[3]} {
+		page := &models.Page{SiteID: siteID, GroupID: groupID, Title: "b", Slug: "child-" + string(rune('0'+i)), ContentFormat: "markdown", ParentID: &parent}
+		if _, err := s.CreatePage(ctx, page); err != nil {
+			t.Fatal(err)
+		}
+	}
+	all, err := s.ListPages(ctx, siteID, groupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compare := func(a, b int) int { return a - b }
+	tests := []struct {
+		sort string
+		cmp  func(a, b *models.Page) int // 음수이면 a 가 앞
+	}{
+		{"-title", func(a, b *models.Page) int { return -compareString(a.Title, b.Title) }},
+		{"title", func(a, b *models.Page) int { return compareString(a.Title, b.Title) }},
+		{"-depth,-menu_order", func(a, b *models.Page) int {
+			if c := compare(b.Depth, a.Depth); c != 0 {
+				return c
+			}
+			return compare(b.MenuOrder, a.MenuOrder)
+		}},
+		{"-depth,title", func(a, b *models.Page) int {
+			if c := compare(b.Depth, a.Depth); c != 0 {
+				return c
+			}
+			return compareString(a.Title, b.Title)
+		}},
+		{"depth,-title", func(a, b *models.Page) int {
+			if c := compare(a.Depth, b.Depth); c != 0 {
+				return c
+			}
+			return -compareString(a.Title, b.Title)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			want := append([]*models.Page{}, all...)
+			sort.Slice(want, func(i, j int) bool {
+				if c := tt.cmp(want[i], want[j]); c != 0 {
+					return c < 0
+				}
+				return want[i].PageID < want[j].PageID
+			})
+
+			for _, limit := range []string{"1", "2", "4"} {
+				var got []int
+				cursor := ""
+				for {
+					q, err := listquery.Parse(url.Values{"sort": {tt.sort}, "limit": {limit}, "cursor": {cursor}}, PageList)
+					if err != nil {
+						t.Fatal(err)
+					}
+					pages, next, err := s.QueryPages(ctx, siteID, groupID, q)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, p := range pages {
+						got = append(got, p.PageID)
+					}
+					if next == "" {
+						break
+					}
+					if len(got) > len(all) {
+						t.Fatalf("limit %s: cursor did not end after %d pages", limit, len(got))
+					}
+					cursor = next
+				}
+
+				if len(got) != len(want) {
+					t.Fatalf("limit %s: got %d pages %v, want %d", limit, len(got), got, len(want))
+				}
+				for i := range want {
+					if got[i] != want[i].PageID {
+						t.Errorf("limit %s: got order %v, want %v", limit, got, ids(want))
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func compareString(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func ids(pages []*models.Page) []int {
+	out := make([]int, len(pages))
+	for i, p := range pages {
+		out[i] = p.PageID
+	}
+	return out
+}
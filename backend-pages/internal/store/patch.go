@@ -0,0 +1,145 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"pages/internal/models"
+	"strings"
+)
+
+// column 은 부분 갱신(PATCH)할 컬럼과 값입니다. name 은 컬럼 이름이자 요청의 json 필드 이름입니다.
+type column struct {
+	name  string
+	value interface{}
+}
+
+// updateColumns 는 columns 중 fields 에 있는 컬럼만 갱신하고 version 과 updated_at 을 바꿉니다.
+// 갱신할 컬럼이 없으면 행이 있는지(version 이 0 이 아니면 버전이 같은지)만 확인합니다.
+func updateColumns(ctx context.Context, tx *sql.Tx, table, key string, id int, columns []column, fields map[string]bool, version int) error {
+	where, whereArgs := versionCondition(key, id, version)
+
+	var sets []string
+	var args []interface{}
+	for _, c := range columns {
+		if fields[c.name] {
+			sets = append(sets, c.name+" = ?")
+			args = append(args, c.value)
+		}
+	}
+	if len(sets) == 0 {
+		var current int
+		err := tx.QueryRowContext(ctx, "SELECT version FROM "+table+" WHERE "+key+" = ?", id).Scan(&current)
+		if err != nil {
+			return notFound(err)
+		}
+		if version != 0 && current != version {
+			return ErrVersionMismatch
+		}
+		return nil
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE "+table+" SET "+strings.Join(sets, ", ")+", version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE "+where,
+		append(args, whereArgs...)...,
+	)
+	if err != nil {
+		return err
+	}
+	return expectVersion(ctx, tx, result, table, key, id)
+}
+
+// PatchPage 는 fields 에 있는 필드만 한 트랜잭션에서 저장합니다. 본문 필드가 바뀌면 새 리비전을 기록하고,
+// parent_id, menu_order 는 MovePage 와 같이 형제와 하위 트리를 다시 계산하며, is_published 는
+// PublishPage, UnpublishPage 와 같이 처리합니다. 공개는 마지막에 하므로 같은 요청의 본문 변경이 공개됩니다.
+func (s *SQLStore) PatchPage(ctx context.Context, pageID int, input models.PatchPageInput, fields map[string]bool, version int) error {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		err := updateColumns(ctx, tx, "pages", "page_id", pageID, []column{
+			{"title", input.Title},
+			{"slug", input.Slug},
+			{"content", input.Content},
+			{"content_format", input.ContentFormat},
+			{"publish_at", utc(input.PublishAt)},
+			{"unpublish_at", utc(input.UnpublishAt)},
+		}, fields, version)
+		if err != nil {
+			return err
+		}
+		if fields["title"] || fields["slug"] || fields["content"] || fields["content_format"] {
+			if err := insertRevision(ctx, tx, pageID); err != nil {
+				return err
+			}
+		}
+
+		if fields["parent_id"] || fields["menu_order"] {
+			move, err := patchPosition(ctx, tx, pageID, input, fields)
+			if err != nil {
+				return err
+			}
+			if err := movePage(ctx, tx, pageID, move); err != nil {
+				return err
+			}
+		}
+
+		if !fields["is_published"] {
+			return nil
+		}
+		if input.IsPublished {
+			return publishPage(ctx, tx, pageID)
+		}
+		return unpublishPage(ctx, tx, pageID)
+	})
+	return conflict(err, ErrSlugConflict)
+}
+
+// patchPosition 은 PATCH 의 parent_id, menu_order 를 MovePage 입력으로 바꿉니다. parent_id 가 없으면
+// 현재 부모를 유지하고, menu_order 가 새 형제 수보다 크거나 0 이면 형제 중 마지막에 둡니다.
+func patchPosition(ctx context.Context, tx *sql.Tx, pageID int, input models.PatchPageInput, fields map[string]bool) (models.MovePageInput, error) {
+	var groupID int
+	err := tx.QueryRowContext(ctx, "SELECT group_id FROM pages WHERE page_id = ?", pageID).Scan(&groupID)
+	if err != nil {
+		return models.MovePageInput{}, notFound(err)
+	}
+	nodes, err := loadGroupTree(ctx, tx, groupID)
+	if err != nil {
+		return models.MovePageInput{}, err
+	}
+
+	move := models.MovePageInput{ParentID: nodes[pageID].parentID}
+	if fields["parent_id"] {
+		move.ParentID = input.ParentID
+	}
+	// input.MenuOrder 는 patch 에 없으면 현재 위치로 채워져 있으므로 patch 에 있을 때만 씁니다.
+	if fields["menu_order"] && input.MenuOrder > 0 {
+		var siblings []*treeNode
+		for _, n := range childrenOf(nodes, move.ParentID) {
+			if n.id != pageID {
+				siblings = append(siblings, n)
+			}
+		}
+		if input.MenuOrder <= len(siblings) {
+			before := siblings[input.MenuOrder-1].id
+			move.BeforeID = &before
+		}
+	}
+	return move, nil
+}
+
+func (s *SQLStore) PatchPageGroup(ctx context.Context, groupID int, input models.PatchPageGroupInput, fields map[string]bool, version int) error {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		return updateColumns(ctx, tx, "page_groups", "group_id", groupID, []column{
+			{"name", input.Name},
+			{"description", input.Description},
+		}, fields, version)
+	})
+	return conflict(err, ErrConflict)
+}
+
+func (s *SQLStore) PatchSite(ctx context.Context, siteID int, input models.PatchSiteInput, fields map[string]bool, version int) error {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		return updateColumns(ctx, tx, "sites", "site_id", siteID, []column{
+			{"name", input.Name},
+			{"domain", siteDomain(input.Domain)},
+		}, fields, version)
+	})
+	return conflict(err, ErrConflict)
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"pages/internal/models"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ftMinTokenSize 는 InnoDB FULLTEXT 인덱스에 들어가는 단어의 최소 글자 수(innodb_ft_min_token_size 기본값)입니다.
+// MariaDB 에는 ngram 파서가 없어 "값", "요금" 처럼 이보다 짧은 단어는 인덱스에 없으므로 LIKE 로 찾습니다.
+const ftMinTokenSize = 3
+
+// SearchPages 는 0009_page_search 의 FULLTEXT 인덱스로 페이지를 검색합니다. MySQL/MariaDB 전용이며
+// SQLite 에서는 search.Index 를 사용합니다. 검색어는 모두 접두어로 일치해야 하고(BOOLEAN MODE 의 +term*),
+// 점수는 제목 일치에 가중치를 더한 값입니다. ftMinTokenSize 보다 짧은 검색어는 제목이나 본문에 포함되어야 합니다.
+// 번역(page_translations)은 검색하지 않습니다.
+func (s *SQLStore) SearchPages(ctx context.Context, q models.SearchQuery) ([]models.SearchHit, int, error) {
+	var long, short []string
+	for _, term := range q.Terms {
+		if utf8.RuneCountInString(term) < ftMinTokenSize {
+			// search.Tokenize 가 문자와 숫자만 남기므로 LIKE 의 %, _ 는 들어오지 않습니다.
+			short = append(short, "%"+term+"%")
+		} else {
+			long = append(long, "+"+term+"*")
+		}
+	}
+	against := strings.Join(long, " ")
+
+	// 공개 필터가 true 이면 공개 스냅샷(page_revisions), 그 밖에는 초안(pages)을 검색합니다.
+	from := "pages p"
+	t := "p"
+	if q.Published != nil && *q.Published {
+		from = "pages p JOIN page_revisions pr ON pr.page_id = p.page_id AND pr.revision = p.published_revision"
+		t = "pr"
+	}
+
+	var where, scores []string
+	var args, scoreArgs []interface{}
+	if against != "" {
+		where = append(where, "MATCH("+t+".title, "+t+".content) AGAINST (? IN BOOLEAN MODE)")
+		args = append(args, against)
+		scores = append(scores, "MATCH("+t+".title) AGAINST (? IN BOOLEAN MODE) * 3 + MATCH("+t+".title, "+t+".content) AGAINST (? IN BOOLEAN MODE)")
+		scoreArgs = append(scoreArgs, against, against)
+	}
+	for _, pattern := range short {
+		where = append(where, "("+t+".title LIKE ? OR "+t+".content LIKE ?)")
+		args = append(args, pattern, pattern)
+		scores = append(scores, "("+t+".title LIKE ?) * 3 + ("+t+".content LIKE ?)")
+		scoreArgs = append(scoreArgs, pattern, pattern)
+	}
+	if q.SiteID != 0 {
+		where = append(where, "p.site_id = ?")
+		args = append(args, q.SiteID)
+	}
+	if q.GroupID != 0 {
+		where = append(where, "p.group_id = ?")
+		args = append(args, q.GroupID)
+	}
+	if q.Published != nil {
+		// models.Page.IsLive 와 같은 조건
+		live := "(p.is_published = TRUE AND p.published_revision IS NOT NULL AND (p.unpublish_at IS NULL OR p.unpublish_at > ?))"
+		if !*q.Published {
+			live = "NOT " + live
+		}
+		now := time.Now()
+		where = append(where, live)
+		args = append(args, utc(&now))
+	}
+	condition := " FROM " + from + " WHERE " + strings.Join(where, " AND ")
+
+	var total int
+	if err := s.conn(ctx).QueryRowContext(ctx, "SELECT COUNT(*)"+condition, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = total
+	}
+	rows, err := s.conn(ctx).QueryContext(ctx, `
+		SELECT p.page_id, p.site_id, p.group_id, `+t+`.slug, p.is_published, `+t+`.title, `+t+`.content, `+t+`.content_format,
+			`+strings.Join(scores, " + ")+` AS score
+		`+condition+`
+		ORDER BY score DESC, p.page_id
+		LIMIT ? OFFSET ?`,
+		append(append(scoreArgs, args...), limit, q.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := rows.Scan(
+			&hit.PageID, &hit.SiteID, &hit.GroupID, &hit.Slug, &hit.IsPublished,
+			&hit.Title, &hit.Content, &hit.ContentFormat, &hit.Score,
+		); err != nil {
+			return nil, 0, err
+		}
+		hits = append(hits, hit)
+	}
+	return hits, total, rows.Err()
+}
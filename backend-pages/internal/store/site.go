@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pages/internal/models"
+)
+
+func (s *SQLStore) GetSiteByAlias(ctx context.Context, code string) (*models.Site, error) {
+	site, err := scanSite(s.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+siteColumns+" FROM sites WHERE site_id = (SELECT site_id FROM site_code_aliases WHERE code = ?)",
+		code,
+	))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return site, nil
+}
+
+func (s *SQLStore) ListSiteAliases(ctx context.Context, siteID int) ([]string, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx,
+		"SELECT code FROM site_code_aliases WHERE site_id = ? ORDER BY created_at, code",
+		siteID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := []string{}
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, code)
+	}
+	return aliases, rows.Err()
+}
+
+// checkAlias 는 code 가 siteID 가 아닌 사이트의 별칭이면 ErrConflict 를 반환합니다.
+// siteID 자신의 별칭이면 코드를 되돌리는 것이므로 별칭을 지웁니다.
+func checkAlias(ctx context.Context, tx *sql.Tx, code string, siteID int) error {
+	var owner int
+	err := tx.QueryRowContext(ctx, "SELECT site_id FROM site_code_aliases WHERE code = ?", code).Scan(&owner)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return err
+	case owner != siteID:
+		return ErrConflict
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM site_code_aliases WHERE code = ?", code)
+	return err
+}
+
+// siteDomain 은 요청의 domain 을 저장할 값으로 바꿉니다. sites.domain 은 NOT NULL 이므로 지운 도메인은 빈 문자열입니다.
+func siteDomain(domain *string) string {
+	if domain == nil {
+		return ""
+	}
+	return *domain
+}
+
+func (s *SQLStore) UpdateSite(ctx context.Context, siteID int, input models.UpdateSiteInput, version int) error {
+	where, args := versionCondition("site_id", siteID, version)
+	result, err := s.conn(ctx).ExecContext(ctx,
+		"UPDATE sites SET name = ?, domain = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE "+where,
+		append([]interface{}{input.Name, siteDomain(input.Domain)}, args...)...,
+	)
+	if err != nil {
+		return conflict(err, ErrConflict)
+	}
+	return expectVersion(ctx, s.conn(ctx), result, "sites", "site_id", siteID)
+}
+
+func (s *SQLStore) RenameSite(ctx context.Context, siteID int, code string, version int) error {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var current string
+		err := tx.QueryRowContext(ctx, "SELECT code FROM sites WHERE site_id = ?", siteID).Scan(&current)
+		if err != nil {
+			return notFound(err)
+		}
+		if err := checkAlias(ctx, tx, code, siteID); err != nil {
+			return err
+		}
+
+		where, args := versionCondition("site_id", siteID, version)
+		result, err := tx.ExecContext(ctx,
+			"UPDATE sites SET code = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE "+where,
+			append([]interface{}{code}, args...)...,
+		)
+		if err != nil {
+			return err
+		}
+		if err := expectVersion(ctx, tx, result, "sites", "site_id", siteID); err != nil {
+			return err
+		}
+		if current == code {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO site_code_aliases (code, site_id) VALUES (?, ?)",
+			current, siteID,
+		)
+		return err
+	})
+	return conflict(err, ErrConflict)
+}
+
+func (s *SQLStore) CountSiteContents(ctx context.Context, siteID int) (*models.SiteDeletion, error) {
+	return countSiteContents(ctx, s.conn(ctx), siteID)
+}
+
+func countSiteContents(ctx context.Context, q rowQueryer, siteID int) (*models.SiteDeletion, error) {
+	deletion := &models.SiteDeletion{SiteID: siteID}
+	err := q.QueryRowContext(ctx, `
+		SELECT s.code,
+			(SELECT COUNT(*) FROM page_groups g WHERE g.site_id = s.site_id),
+			(SELECT COUNT(*) FROM pages p WHERE p.site_id = s.site_id)
+		FROM sites s WHERE s.site_id = ?`,
+		siteID,
+	).Scan(&deletion.Code, &deletion.PageGroups, &deletion.Pages)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return deletion, nil
+}
+
+// DeleteSite 는 같은 트랜잭션에서 센 수를 반환하므로 응답의 수는 실제로 지운 행과 같습니다.
+// 그룹, 페이지, 리비전, 번역과 사이트 설정은 외래 키의 ON DELETE CASCADE 로 지워집니다.
+func (s *SQLStore) DeleteSite(ctx context.Context, siteID int, version int) (*models.SiteDeletion, error) {
+	var deletion *models.SiteDeletion
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if deletion, err = countSiteContents(ctx, tx, siteID); err != nil {
+			return err
+		}
+
+		where, args := versionCondition("site_id", siteID, version)
+		result, err := tx.ExecContext(ctx, "DELETE FROM sites WHERE "+where, args...)
+		if err != nil {
+			return err
+		}
+		return expectVersion(ctx, tx, result, "sites", "site_id", siteID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
@@ -0,0 +1,753 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"pages/internal/models"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// SQLStore 는 database/sql 위에서 SiteStore, PageGroupStore, PageStore 를 구현합니다.
+// 쿼리는 MySQL/MariaDB 와 SQLite 에서 모두 동작하는 SQL 로 작성합니다.
+type SQLStore struct {
+	db *sql.DB
+	// sqlite 는 db 가 SQLite 인지 나타냅니다. 시각 인자의 형식을 맞출 때 씁니다(timeArg).
+	sqlite bool
+}
+
+var (
+	_ SiteStore      = (*SQLStore)(nil)
+	_ PageGroupStore = (*SQLStore)(nil)
+	_ PageStore      = (*SQLStore)(nil)
+)
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	_, isSQLite := db.Driver().(*sqlite.Driver)
+	return &SQLStore{db: db, sqlite: isSQLite}
+}
+
+const (
+	siteColumns  = "site_id, code, name, domain, version, created_at, updated_at"
+	groupColumns = "group_id, site_id, name, description, version, created_at, updated_at"
+	pageColumns  = `p.page_id, p.site_id, p.group_id, p.title, p.slug, p.parent_id, p.depth,
+		p.menu_order, p.content, p.content_format,
+		(SELECT MAX(r.revision) FROM page_revisions r WHERE r.page_id = p.page_id),
+		p.is_published, p.published_revision, p.published_at,
+		pr.title, pr.slug, pr.content, pr.content_format, pr.created_at, p.publish_at, p.unpublish_at,
+		p.version, p.created_at, p.updated_at`
+	// pageTables 는 pageColumns 와 함께 쓰며, 공개 스냅샷 리비전을 조인합니다.
+	pageTables = `pages p
+		LEFT JOIN page_revisions pr ON pr.page_id = p.page_id AND pr.revision = p.published_revision`
+	revisionColumns = "page_id, revision, title, slug, content, content_format, created_at"
+)
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// rowQueryer 는 *sql.DB 와 *sql.Tx 의 공통 조회 메서드입니다.
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func scanSite(row rowScanner) (*models.Site, error) {
+	var site models.Site
+	if err := row.Scan(&site.SiteID, &site.Code, &site.Name, &site.Domain, &site.Version, &site.CreatedAt, &site.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &site, nil
+}
+
+func scanPageGroup(row rowScanner) (*models.PageGroup, error) {
+	var group models.PageGroup
+	if err := row.Scan(&group.GroupID, &group.SiteID, &group.Name, &group.Description, &group.Version, &group.CreatedAt, &group.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func scanPage(row rowScanner) (*models.Page, error) {
+	var page models.Page
+	var revision sql.NullInt64
+	var pubTitle, pubSlug, pubContent, pubFormat sql.NullString
+	var pubCreatedAt sql.NullTime
+	if err := row.Scan(
+		&page.PageID, &page.SiteID, &page.GroupID, &page.Title, &page.Slug,
+		&page.ParentID, &page.Depth, &page.MenuOrder, &page.Content, &page.ContentFormat, &revision,
+		&page.IsPublished, &page.PublishedRevision, &page.PublishedAt,
+		&pubTitle, &pubSlug, &pubContent, &pubFormat, &pubCreatedAt,
+		&page.PublishAt, &page.UnpublishAt, &page.Version, &page.CreatedAt, &page.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	page.Revision = int(revision.Int64)
+	if page.PublishedRevision != nil && pubTitle.Valid {
+		page.Published = &models.PageRevision{
+			PageID:        page.PageID,
+			Revision:      *page.PublishedRevision,
+			Title:         pubTitle.String,
+			Slug:          pubSlug.String,
+			Content:       pubContent.String,
+			ContentFormat: pubFormat.String,
+			CreatedAt:     pubCreatedAt.Time,
+		}
+	}
+	return &page, nil
+}
+
+// txKey 는 Begin 이 트랜잭션을 담는 컨텍스트 키입니다.
+type txKey struct{}
+
+// ctxTx 는 컨텍스트에 담긴 트랜잭션입니다. 커밋하거나 되돌린 뒤에는 같은 컨텍스트의 조회를 db 로 실행합니다.
+type ctxTx struct {
+	*sql.Tx
+	done bool
+}
+
+func (t *ctxTx) Commit() error {
+	t.done = true
+	return t.Tx.Commit()
+}
+
+func (t *ctxTx) Rollback() error {
+	if t.done {
+		return nil
+	}
+	t.done = true
+	return t.Tx.Rollback()
+}
+
+// Begin 은 트랜잭션을 시작하고 그 트랜잭션을 담은 컨텍스트를 반환합니다. 이 컨텍스트로 부르는 저장소
+// 메서드는 커밋하거나 되돌릴 때까지 모두 이 트랜잭션 안에서 실행됩니다.
+func (s *SQLStore) Begin(ctx context.Context) (context.Context, Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ctx, nil, err
+	}
+	t := &ctxTx{Tx: tx}
+	return context.WithValue(ctx, txKey{}, t), t, nil
+}
+
+// Commit 은 ctx 에 Begin 으로 시작한 트랜잭션이 있으면 커밋합니다. 없거나 이미 끝났으면 아무것도 하지 않습니다.
+func Commit(ctx context.Context) error {
+	if t, ok := ctx.Value(txKey{}).(*ctxTx); ok && !t.done {
+		return t.Commit()
+	}
+	return nil
+}
+
+// activeTx 는 ctx 에 담긴 끝나지 않은 트랜잭션입니다. 없으면 nil 입니다.
+func activeTx(ctx context.Context) *sql.Tx {
+	if t, ok := ctx.Value(txKey{}).(*ctxTx); ok && !t.done {
+		return t.Tx
+	}
+	return nil
+}
+
+// dbConn 은 *sql.DB 와 *sql.Tx 의 공통 메서드입니다.
+type dbConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conn 은 ctx 에 Begin 으로 시작한 트랜잭션이 있으면 그 트랜잭션을, 없으면 db 를 반환합니다.
+func (s *SQLStore) conn(ctx context.Context) dbConn {
+	if tx := activeTx(ctx); tx != nil {
+		return tx
+	}
+	return s.db
+}
+
+// withTx 는 fn 을 트랜잭션 안에서 실행합니다. ctx 에 Begin 으로 시작한 트랜잭션이 있으면 그 트랜잭션에서
+// 실행하며 커밋은 Begin 을 부른 쪽이 합니다.
+func (s *SQLStore) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if tx := activeTx(ctx); tx != nil {
+		return fn(tx)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func scanRevision(row rowScanner) (*models.PageRevision, error) {
+	var rev models.PageRevision
+	if err := row.Scan(&rev.PageID, &rev.Revision, &rev.Title, &rev.Slug, &rev.Content, &rev.ContentFormat, &rev.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// utc 는 시각을 UTC 로 맞춥니다. SQLite 는 시각을 문자열로 저장하므로
+// 비교가 올바르게 되도록 모든 시각을 같은 시간대로 기록합니다.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// versionCondition 은 key 가 id 인 행의 WHERE 조건을 만듭니다. version 이 0 이 아니면 버전도 같아야 합니다.
+func versionCondition(key string, id, version int) (string, []interface{}) {
+	if version == 0 {
+		return key + " = ?", []interface{}{id}
+	}
+	return key + " = ? AND version = ?", []interface{}{id, version}
+}
+
+// expectVersion 은 versionCondition 으로 수정, 삭제한 결과에 영향받은 행이 없을 때
+// 행이 없으면 ErrNotFound 를, 행은 있지만 버전이 달랐으면 ErrVersionMismatch 를 반환합니다.
+func expectVersion(ctx context.Context, q rowQueryer, result sql.Result, table, key string, id int) error {
+	if n, err := result.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+	var exists int
+	if err := q.QueryRowContext(ctx, "SELECT 1 FROM "+table+" WHERE "+key+" = ?", id).Scan(&exists); err != nil {
+		return notFound(err)
+	}
+	return ErrVersionMismatch
+}
+
+// notFound 는 sql.ErrNoRows 를 ErrNotFound 로 바꿉니다.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// conflict 는 고유 제약(UNIQUE, PRIMARY KEY) 위반 오류를 target 으로 바꿉니다.
+func conflict(err error, target error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 { // ER_DUP_ENTRY
+		return target
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) &&
+		(sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+		return target
+	}
+	return err
+}
+
+// expectRows 는 UPDATE/DELETE 결과에 영향받은 행이 없으면 ErrNotFound 를 반환합니다.
+func expectRows(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLStore) ListSites(ctx context.Context) ([]models.Site, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, "SELECT "+siteColumns+" FROM sites")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sites []models.Site
+	for rows.Next() {
+		site, err := scanSite(rows)
+		if err != nil {
+			return nil, err
+		}
+		sites = append(sites, *site)
+	}
+	return sites, rows.Err()
+}
+
+func (s *SQLStore) GetSiteByCode(ctx context.Context, code string) (*models.Site, error) {
+	site, err := scanSite(s.conn(ctx).QueryRowContext(ctx, "SELECT "+siteColumns+" FROM sites WHERE code = ?", code))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return site, nil
+}
+
+func (s *SQLStore) GetSiteByDomain(ctx context.Context, domain string) (*models.Site, error) {
+	site, err := scanSite(s.conn(ctx).QueryRowContext(ctx, "SELECT "+siteColumns+" FROM sites WHERE domain = ? AND domain <> ''", domain))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return site, nil
+}
+
+func (s *SQLStore) GetSiteTheme(ctx context.Context, siteID int) (*models.SiteTheme, error) {
+	var theme models.SiteTheme
+	err := s.conn(ctx).QueryRowContext(ctx,
+		"SELECT site_id, template, updated_at FROM site_themes WHERE site_id = ?",
+		siteID,
+	).Scan(&theme.SiteID, &theme.Template, &theme.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &theme, nil
+}
+
+// SaveSiteTheme 은 MySQL 과 SQLite 의 upsert 문법이 달라 삭제 후 다시 추가합니다.
+func (s *SQLStore) SaveSiteTheme(ctx context.Context, siteID int, template string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM site_themes WHERE site_id = ?", siteID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO site_themes (site_id, template) VALUES (?, ?)",
+			siteID, template,
+		)
+		return err
+	})
+}
+
+func (s *SQLStore) GetSiteRobots(ctx context.Context, siteID int) (*models.SiteRobots, error) {
+	var robots models.SiteRobots
+	err := s.conn(ctx).QueryRowContext(ctx,
+		"SELECT site_id, content, updated_at FROM site_robots WHERE site_id = ?",
+		siteID,
+	).Scan(&robots.SiteID, &robots.Content, &robots.UpdatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &robots, nil
+}
+
+// SaveSiteRobots 는 SaveSiteTheme 과 같이 삭제 후 다시 추가합니다. content 가 비어 있으면 삭제만 합니다.
+func (s *SQLStore) SaveSiteRobots(ctx context.Context, siteID int, content string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM site_robots WHERE site_id = ?", siteID); err != nil {
+			return err
+		}
+		if content == "" {
+			return nil
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO site_robots (site_id, content) VALUES (?, ?)",
+			siteID, content,
+		)
+		return err
+	})
+}
+
+func (s *SQLStore) GetContentPolicy(ctx context.Context, siteID int) (*models.ContentPolicy, error) {
+	var data string
+	var updatedAt time.Time
+	err := s.conn(ctx).QueryRowContext(ctx,
+		"SELECT policy, updated_at FROM site_content_policies WHERE site_id = ?",
+		siteID,
+	).Scan(&data, &updatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	var policy models.ContentPolicy
+	if err := json.Unmarshal([]byte(data), &policy); err != nil {
+		return nil, err
+	}
+	policy.UpdatedAt = &updatedAt
+	return &policy, nil
+}
+
+// SaveContentPolicy 는 SaveSiteTheme 과 같이 삭제 후 다시 추가합니다.
+func (s *SQLStore) SaveContentPolicy(ctx context.Context, siteID int, policy models.ContentPolicy) error {
+	policy.UpdatedAt = nil
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM site_content_policies WHERE site_id = ?", siteID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO site_content_policies (site_id, policy) VALUES (?, ?)",
+			siteID, string(data),
+		)
+		return err
+	})
+}
+
+func (s *SQLStore) CreateSite(ctx context.Context, input models.CreateSiteInput) (int64, error) {
+	var id int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := checkAlias(ctx, tx, input.Code, 0); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx,
+			"INSERT INTO sites (code, name, domain) VALUES (?, ?, ?)",
+			input.Code, input.Name, input.Domain,
+		)
+		if err != nil {
+			return err
+		}
+		id, err = result.LastInsertId()
+		return err
+	})
+	return id, conflict(err, ErrConflict)
+}
+
+func (s *SQLStore) ListPageGroups(ctx context.Context, siteID int) ([]models.PageGroup, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx,
+		"SELECT "+groupColumns+" FROM page_groups WHERE site_id = ? ORDER BY name",
+		siteID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []models.PageGroup
+	for rows.Next() {
+		group, err := scanPageGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *group)
+	}
+	return groups, rows.Err()
+}
+
+func (s *SQLStore) CreatePageGroup(ctx context.Context, siteID int, input models.CreatePageGroupInput) (int64, error) {
+	result, err := s.conn(ctx).ExecContext(ctx,
+		"INSERT INTO page_groups (site_id, name, description) VALUES (?, ?, ?)",
+		siteID, input.Name, input.Description,
+	)
+	if err != nil {
+		return 0, conflict(err, ErrConflict)
+	}
+	return result.LastInsertId()
+}
+
+func (s *SQLStore) GetPageGroup(ctx context.Context, groupID int) (*models.PageGroup, error) {
+	group, err := scanPageGroup(s.conn(ctx).QueryRowContext(ctx, "SELECT "+groupColumns+" FROM page_groups WHERE group_id = ?", groupID))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return group, nil
+}
+
+func (s *SQLStore) UpdatePageGroup(ctx context.Context, groupID int, input models.UpdatePageGroupInput, version int) error {
+	where, args := versionCondition("group_id", groupID, version)
+	result, err := s.conn(ctx).ExecContext(ctx,
+		"UPDATE page_groups SET name = ?, description = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE "+where,
+		append([]interface{}{input.Name, input.Description}, args...)...,
+	)
+	if err != nil {
+		return conflict(err, ErrConflict)
+	}
+	return expectVersion(ctx, s.conn(ctx), result, "page_groups", "group_id", groupID)
+}
+
+func (s *SQLStore) DeletePageGroup(ctx context.Context, groupID int, version int) error {
+	where, args := versionCondition("group_id", groupID, version)
+	result, err := s.conn(ctx).ExecContext(ctx, "DELETE FROM page_groups WHERE "+where, args...)
+	if err != nil {
+		return err
+	}
+	return expectVersion(ctx, s.conn(ctx), result, "page_groups", "group_id", groupID)
+}
+
+func (s *SQLStore) ListPages(ctx context.Context, siteID, groupID int) ([]*models.Page, error) {
+	return s.queryPages(ctx,
+		"SELECT "+pageColumns+" FROM "+pageTables+`
+		WHERE p.site_id = ? AND p.group_id = ?
+		ORDER BY p.depth, p.menu_order`,
+		siteID, groupID,
+	)
+}
+
+func (s *SQLStore) ListSitePages(ctx context.Context, siteID int) ([]*models.Page, error) {
+	return s.queryPages(ctx,
+		"SELECT "+pageColumns+" FROM "+pageTables+`
+		WHERE p.site_id = ?
+		ORDER BY p.group_id, p.depth, p.menu_order`,
+		siteID,
+	)
+}
+
+func (s *SQLStore) queryPages(ctx context.Context, query string, args ...interface{}) ([]*models.Page, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pages := []*models.Page{}
+	for rows.Next() {
+		page, err := scanPage(rows)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, rows.Err()
+}
+
+func (s *SQLStore) GetPage(ctx context.Context, pageID int) (*models.Page, error) {
+	page, err := scanPage(s.conn(ctx).QueryRowContext(ctx, "SELECT "+pageColumns+" FROM "+pageTables+" WHERE p.page_id = ?", pageID))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return page, nil
+}
+
+func (s *SQLStore) CreatePage(ctx context.Context, page *models.Page) (int64, error) {
+	var id int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := placeNewPage(ctx, tx, page); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO pages (site_id, group_id, title, slug, parent_id, depth, menu_order, content, content_format,
+				is_published, publish_at, unpublish_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			page.SiteID, page.GroupID, page.Title, page.Slug, page.ParentID,
+			page.Depth, page.MenuOrder, page.Content, page.ContentFormat, page.IsPublished,
+			utc(page.PublishAt), utc(page.UnpublishAt),
+		)
+		if err != nil {
+			return err
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		return insertRevision(ctx, tx, int(id))
+	})
+	return id, conflict(err, ErrSlugConflict)
+}
+
+func (s *SQLStore) UpdatePage(ctx context.Context, page *models.Page) error {
+	where, args := versionCondition("page_id", page.PageID, page.Version)
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE pages
+			SET title = ?, slug = ?, content = ?, content_format = COALESCE(NULLIF(?, ''), content_format),
+				publish_at = ?, unpublish_at = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+			WHERE `+where,
+			append([]interface{}{page.Title, page.Slug, page.Content, page.ContentFormat, utc(page.PublishAt), utc(page.UnpublishAt)}, args...)...,
+		)
+		if err != nil {
+			return err
+		}
+		if err := expectVersion(ctx, tx, result, "pages", "page_id", page.PageID); err != nil {
+			return err
+		}
+		return insertRevision(ctx, tx, page.PageID)
+	})
+	return conflict(err, ErrSlugConflict)
+}
+
+func (s *SQLStore) DeletePage(ctx context.Context, pageID int, version int) error {
+	where, args := versionCondition("page_id", pageID, version)
+	result, err := s.conn(ctx).ExecContext(ctx, "DELETE FROM pages WHERE "+where, args...)
+	if err != nil {
+		return err
+	}
+	return expectVersion(ctx, s.conn(ctx), result, "pages", "page_id", pageID)
+}
+
+func (s *SQLStore) PublishPage(ctx context.Context, pageID int) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return publishPage(ctx, tx, pageID)
+	})
+}
+
+func publishPage(ctx context.Context, tx *sql.Tx, pageID int) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE pages
+		SET published_revision = (SELECT MAX(r.revision) FROM page_revisions r WHERE r.page_id = pages.page_id),
+			published_at = CURRENT_TIMESTAMP,
+			is_published = true,
+			version = version + 1
+		WHERE page_id = ?
+	`, pageID)
+	if err != nil {
+		return err
+	}
+	if err := expectRows(result); err != nil {
+		return err
+	}
+	return publishTranslations(ctx, tx, "page_id = ?", pageID)
+}
+
+func (s *SQLStore) UnpublishPage(ctx context.Context, pageID int) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return unpublishPage(ctx, tx, pageID)
+	})
+}
+
+func unpublishPage(ctx context.Context, tx *sql.Tx, pageID int) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE pages SET is_published = false, version = version + 1 WHERE page_id = ?", pageID); err != nil {
+		return err
+	}
+	// 이미 비공개인 페이지는 영향받은 행이 없으므로 존재 여부를 따로 확인합니다.
+	var exists int
+	err := tx.QueryRowContext(ctx, "SELECT 1 FROM pages WHERE page_id = ?", pageID).Scan(&exists)
+	return notFound(err)
+}
+
+// PublishScheduled 는 publish_at 이 now 이전인 페이지의 최신 리비전을 공개하고 publish_at 을 비웁니다.
+// 페이지마다 조건을 다시 확인하며 갱신하므로 여러 인스턴스가 동시에 실행해도 한 번만 처리되고 이력도 한 번만 남습니다.
+// 번역은 같은 조건으로 먼저 복사하며, 다른 인스턴스와 겹쳐 두 번 복사되어도 결과는 같습니다.
+func (s *SQLStore) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	var published int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		err := publishTranslations(ctx, tx,
+			"page_id IN (SELECT page_id FROM pages WHERE publish_at IS NOT NULL AND publish_at <= ?)", now.UTC())
+		if err != nil {
+			return err
+		}
+		published, err = applySchedule(ctx, tx, "publish_at", models.AuditPublish, `
+			UPDATE pages
+			SET published_revision = (SELECT MAX(r.revision) FROM page_revisions r WHERE r.page_id = pages.page_id),
+				published_at = ?,
+				is_published = true,
+				publish_at = NULL,
+				version = version + 1
+			WHERE page_id = ? AND publish_at IS NOT NULL AND publish_at <= ?
+		`, now)
+		return err
+	})
+	return published, err
+}
+
+// UnpublishExpired 는 unpublish_at 이 now 이전인 페이지를 비공개로 바꾸고 unpublish_at 을 비웁니다.
+func (s *SQLStore) UnpublishExpired(ctx context.Context, now time.Time) (int64, error) {
+	var unpublished int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		unpublished, err = applySchedule(ctx, tx, "unpublish_at", models.AuditUnpublish, `
+			UPDATE pages
+			SET is_published = false, unpublish_at = NULL, version = version + 1
+			WHERE page_id = ? AND unpublish_at IS NOT NULL AND unpublish_at <= ?
+		`, now)
+		return err
+	})
+	return unpublished, err
+}
+
+// applySchedule 은 column(publish_at, unpublish_at)이 now 이전인 페이지마다 update 를 실행하고, 바뀐 페이지의
+// 전후 스냅샷을 스케줄러의 action 이력으로 남깁니다. update 의 인자는 publish_at 이면 (now, page_id, now),
+// unpublish_at 이면 (page_id, now) 입니다. 바뀐 페이지 수를 반환합니다.
+func applySchedule(ctx context.Context, tx *sql.Tx, column, action, update string, now time.Time) (int64, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT "+pageColumns+" FROM "+pageTables+" WHERE p."+column+" IS NOT NULL AND p."+column+" <= ?", now.UTC())
+	if err != nil {
+		return 0, err
+	}
+	var due []*models.Page
+	for rows.Next() {
+		page, err := scanPage(rows)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		due = append(due, page)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var changed int64
+	for _, before := range due {
+		args := []interface{}{before.PageID, now.UTC()}
+		if column == "publish_at" {
+			args = append([]interface{}{now.UTC()}, args...)
+		}
+		result, err := tx.ExecContext(ctx, update, args...)
+		if err != nil {
+			return 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			continue // 다른 인스턴스가 먼저 처리했습니다.
+		}
+		after, err := scanPage(tx.QueryRowContext(ctx, "SELECT "+pageColumns+" FROM "+pageTables+" WHERE p.page_id = ?", before.PageID))
+		if err != nil {
+			return 0, err
+		}
+		event := &models.AuditEvent{
+			Actor:      models.AuditActorScheduler,
+			ActorName:  models.AuditActorScheduler,
+			SiteID:     &before.SiteID,
+			EntityType: models.EntityPage,
+			EntityID:   before.PageID,
+			Action:     action,
+		}
+		if event.Before, err = json.Marshal(before); err != nil {
+			return 0, err
+		}
+		if event.After, err = json.Marshal(after); err != nil {
+			return 0, err
+		}
+		if err := insertAuditEvent(ctx, tx, event); err != nil {
+			return 0, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// insertRevision 은 페이지의 현재 title, slug, content, content_format 을 다음 리비전 번호로 기록합니다.
+func insertRevision(ctx context.Context, tx *sql.Tx, pageID int) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO page_revisions (page_id, revision, title, slug, content, content_format)
+		SELECT p.page_id,
+			(SELECT COALESCE(MAX(r.revision), 0) + 1 FROM page_revisions r WHERE r.page_id = p.page_id),
+			p.title, p.slug, p.content, p.content_format
+		FROM pages p WHERE p.page_id = ?
+	`, pageID)
+	return err
+}
+
+func (s *SQLStore) ListRevisions(ctx context.Context, pageID int) ([]models.PageRevision, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx,
+		"SELECT "+revisionColumns+" FROM page_revisions WHERE page_id = ? ORDER BY revision DESC",
+		pageID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []models.PageRevision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	return revisions, rows.Err()
+}
+
+func (s *SQLStore) GetRevision(ctx context.Context, pageID, revision int) (*models.PageRevision, error) {
+	rev, err := scanRevision(s.conn(ctx).QueryRowContext(ctx,
+		"SELECT "+revisionColumns+" FROM page_revisions WHERE page_id = ? AND revision = ?",
+		pageID, revision,
+	))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return rev, nil
+}
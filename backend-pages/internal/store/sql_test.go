@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"pages/internal/migrate"
+	"pages/internal/models"
+	"testing"
+)
+
+// newTestStore 는 마이그레이션을 적용한 메모리 SQLite 로 SQLStore 를 만듭니다.
+func newTestStore(t *testing.T) *SQLStore {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// database.Open 과 같이 연결을 하나로 묶습니다. 메모리 DB 는 연결마다 따로 생깁니다.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migrate.New(db, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return NewSQLStore(db)
+}
+
+// testGroup 은 사이트 하나와 그 그룹 하나를 만들고 siteID, groupID 를 반환합니다.
+func testGroup(t *testing.T, s *SQLStore) (int, int) {
+	t.Helper()
+	ctx := context.Background()
+	siteID, err := s.CreateSite(ctx, models.CreateSiteInput{Code: "test", Name: "Test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupID, err := s.CreatePageGroup(ctx, int(siteID), models.CreatePageGroupInput{Name: "Main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int(siteID), int(groupID)
+}
+
+// testPage 는 parentID 아래 마지막 형제로 페이지를 만들고 page_id 를 반환합니다. parentID 가 0 이면 최상위입니다.
+func testPage(t *testing.T, s *SQLStore, siteID, groupID, parentID int, slug string) int {
+	t.Helper()
+	page := &models.Page{SiteID: siteID, GroupID: groupID, Title: slug, Slug: slug, ContentFormat: "markdown"}
+	if parentID != 0 {
+		page.ParentID = &parentID
+	}
+	id, err := s.CreatePage(context.Background(), page)
+	if err != nil {
+		t.Fatalf("CreatePage %s: %v", slug, err)
+	}
+	return int(id)
+}
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pages/internal/listquery"
+	"pages/internal/models"
+)
+
+var (
+	// ErrNotFound 는 조회/수정/삭제 대상 행이 없을 때 반환됩니다.
+	ErrNotFound = errors.New("store: not found")
+	// ErrInvalidParent 는 부모 페이지가 없거나 다른 그룹에 속할 때 반환됩니다.
+	ErrInvalidParent = errors.New("store: invalid parent page")
+	// ErrCycle 은 페이지를 자기 자신이나 하위 페이지 아래로 옮기려 할 때 반환됩니다.
+	ErrCycle = errors.New("store: page cannot be moved under itself")
+	// ErrInvalidPosition 은 기준 형제 페이지가 새 부모의 하위 페이지가 아닐 때 반환됩니다.
+	ErrInvalidPosition = errors.New("store: invalid sibling position")
+	// ErrInvalidTree 는 저장할 메뉴 트리가 그룹의 페이지 구성과 맞지 않을 때 반환됩니다.
+	ErrInvalidTree = errors.New("store: invalid menu tree")
+	// ErrSlugConflict 는 같은 부모 아래에 같은 slug 의 페이지가 이미 있을 때 반환됩니다.
+	ErrSlugConflict = errors.New("store: slug conflict")
+	// ErrConflict 는 사이트 코드, 그룹 이름처럼 고유해야 하는 값이 이미 있을 때 반환됩니다.
+	ErrConflict = errors.New("store: duplicate key")
+	// ErrVersionMismatch 는 조건부 수정, 삭제에서 행의 현재 버전이 요청한 버전과 다를 때 반환됩니다.
+	ErrVersionMismatch = errors.New("store: version mismatch")
+)
+
+// ReasonError 는 검사 오류 Err 에 이유를 덧붙입니다. Reason 은 고정된 식별자(예: missing_pages)이고
+// Args 는 이유의 인자(페이지 ID, 개수)입니다. 핸들러는 이를 번역해 details 로 보냅니다.
+type ReasonError struct {
+	Err    error
+	Reason string
+	Args   []interface{}
+}
+
+func (e *ReasonError) Error() string {
+	return fmt.Sprintf("%v: %s %v", e.Err, e.Reason, e.Args)
+}
+
+func (e *ReasonError) Unwrap() error {
+	return e.Err
+}
+
+// SiteStore 는 sites 테이블에 대한 접근을 추상화합니다.
+// version 인자는 PageGroupStore 와 같이 0 이 아니면 행의 현재 버전이 같을 때만 반영합니다.
+type SiteStore interface {
+	ListSites(ctx context.Context) ([]models.Site, error)
+	// QuerySites 는 q 의 필터, 정렬에 맞는 사이트 한 쪽과 다음 커서(마지막이면 빈 문자열)를 반환합니다.
+	// 커서 행이 지워졌으면 listquery.ErrCursor 를 반환합니다. 정렬, 필터는 SiteList 를 따릅니다.
+	QuerySites(ctx context.Context, q *listquery.Query) ([]models.Site, string, error)
+	GetSiteByCode(ctx context.Context, code string) (*models.Site, error)
+	GetSiteByDomain(ctx context.Context, domain string) (*models.Site, error)
+	// GetSiteByAlias 는 바꾸기 전의 코드 code 로 사이트를 조회합니다. 별칭이 없으면 ErrNotFound 를 반환합니다.
+	GetSiteByAlias(ctx context.Context, code string) (*models.Site, error)
+	// ListSiteAliases 는 사이트의 옛 코드를 바꾼 순서대로 반환합니다.
+	ListSiteAliases(ctx context.Context, siteID int) ([]string, error)
+	// CreateSite 는 code 가 다른 사이트의 코드나 별칭이면 ErrConflict 를 반환합니다.
+	CreateSite(ctx context.Context, input models.CreateSiteInput) (int64, error)
+	UpdateSite(ctx context.Context, siteID int, input models.UpdateSiteInput, version int) error
+	// PatchSite 는 fields(json 필드 이름)에 있는 필드만 저장합니다.
+	PatchSite(ctx context.Context, siteID int, input models.PatchSiteInput, fields map[string]bool, version int) error
+	// RenameSite 는 사이트 코드를 code 로 바꾸고 이전 코드를 별칭으로 남깁니다. code 가 다른 사이트의
+	// 코드나 별칭이면 ErrConflict 를 반환하며, 이 사이트의 별칭이면 별칭을 지우고 되돌립니다.
+	RenameSite(ctx context.Context, siteID int, code string, version int) error
+	// CountSiteContents 는 사이트를 지우면 함께 지워질 그룹과 페이지 수를 반환합니다.
+	CountSiteContents(ctx context.Context, siteID int) (*models.SiteDeletion, error)
+	// DeleteSite 는 사이트와 그 그룹, 페이지, 설정을 모두 지우고 지운 그룹과 페이지 수를 반환합니다.
+	DeleteSite(ctx context.Context, siteID int, version int) (*models.SiteDeletion, error)
+
+	// GetSiteTheme 은 사이트 테마가 없으면 ErrNotFound 를 반환합니다.
+	GetSiteTheme(ctx context.Context, siteID int) (*models.SiteTheme, error)
+	SaveSiteTheme(ctx context.Context, siteID int, template string) error
+
+	// GetSiteRobots 는 사이트 robots.txt 가 없으면 ErrNotFound 를 반환합니다.
+	GetSiteRobots(ctx context.Context, siteID int) (*models.SiteRobots, error)
+	// SaveSiteRobots 는 content 가 비어 있으면 저장된 robots.txt 를 지웁니다.
+	SaveSiteRobots(ctx context.Context, siteID int, content string) error
+
+	// GetContentPolicy 는 사이트 본문 정책이 없으면 ErrNotFound 를 반환합니다.
+	GetContentPolicy(ctx context.Context, siteID int) (*models.ContentPolicy, error)
+	SaveContentPolicy(ctx context.Context, siteID int, policy models.ContentPolicy) error
+
+	// GetSiteLocales 는 사이트 언어 설정이 없으면 ErrNotFound 를 반환합니다.
+	GetSiteLocales(ctx context.Context, siteID int) (*models.SiteLocales, error)
+	SaveSiteLocales(ctx context.Context, siteID int, locales models.SiteLocales) error
+}
+
+// PageGroupStore 는 page_groups 테이블에 대한 접근을 추상화합니다.
+// version 인자가 0 이 아닌 수정, 삭제는 행의 현재 버전이 같을 때만 반영하고, 다르면 ErrVersionMismatch 를 반환합니다.
+type PageGroupStore interface {
+	ListPageGroups(ctx context.Context, siteID int) ([]models.PageGroup, error)
+	// QueryPageGroups 는 QuerySites 와 같은 방식으로 사이트의 그룹 한 쪽을 반환합니다(PageGroupList).
+	QueryPageGroups(ctx context.Context, siteID int, q *listquery.Query) ([]models.PageGroup, string, error)
+	GetPageGroup(ctx context.Context, groupID int) (*models.PageGroup, error)
+	CreatePageGroup(ctx context.Context, siteID int, input models.CreatePageGroupInput) (int64, error)
+	UpdatePageGroup(ctx context.Context, groupID int, input models.UpdatePageGroupInput, version int) error
+	// PatchPageGroup 은 fields(json 필드 이름)에 있는 필드만 저장합니다.
+	PatchPageGroup(ctx context.Context, groupID int, input models.PatchPageGroupInput, fields map[string]bool, version int) error
+	DeletePageGroup(ctx context.Context, groupID int, version int) error
+}
+
+// PageStore 는 pages 테이블에 대한 접근을 추상화합니다.
+// 페이지를 바꾸는 모든 쓰기(번역 포함)는 pages.version 을 올립니다. version 인자(UpdatePage 는 page.Version)가
+// 0 이 아닌 수정, 삭제는 현재 버전이 같을 때만 반영하고, 다르면 ErrVersionMismatch 를 반환합니다.
+type PageStore interface {
+	// ListPages 는 그룹의 페이지를 depth, menu_order 순으로 반환합니다.
+	ListPages(ctx context.Context, siteID, groupID int) ([]*models.Page, error)
+	// QueryPages 는 QuerySites 와 같은 방식으로 그룹의 페이지 한 쪽을 반환합니다(PageList).
+	QueryPages(ctx context.Context, siteID, groupID int, q *listquery.Query) ([]*models.Page, string, error)
+	// ListSitePages 는 사이트의 모든 그룹의 페이지를 group_id, depth, menu_order 순으로 반환합니다.
+	ListSitePages(ctx context.Context, siteID int) ([]*models.Page, error)
+	GetPage(ctx context.Context, pageID int) (*models.Page, error)
+	// CreatePage 는 depth 를 부모 기준으로, menu_order 를 형제 중 마지막으로 정해 저장합니다.
+	CreatePage(ctx context.Context, page *models.Page) (int64, error)
+	// UpdatePage 는 page.PageID 에 해당하는 페이지의 title, slug, content, publish_at, unpublish_at 을 갱신합니다.
+	// page.ContentFormat 이 비어 있으면 content_format 은 그대로 둡니다.
+	UpdatePage(ctx context.Context, page *models.Page) error
+	// PatchPage 는 fields(json 필드 이름)에 있는 필드만 한 트랜잭션에서 저장합니다.
+	// 본문 필드가 바뀌면 새 리비전을 기록하고, parent_id, menu_order 는 MovePage 와 같이 트리를 다시 계산하며,
+	// is_published 는 PublishPage, UnpublishPage 와 같이 처리합니다.
+	PatchPage(ctx context.Context, pageID int, input models.PatchPageInput, fields map[string]bool, version int) error
+	DeletePage(ctx context.Context, pageID int, version int) error
+
+	// CreatePage 와 UpdatePage 는 같은 트랜잭션에서 page_revisions 에 새 리비전을 기록합니다.
+	ListRevisions(ctx context.Context, pageID int) ([]models.PageRevision, error)
+	GetRevision(ctx context.Context, pageID, revision int) (*models.PageRevision, error)
+
+	// ListTranslations 는 페이지의 번역을 locale 순으로 반환합니다.
+	ListTranslations(ctx context.Context, pageID int) ([]models.PageTranslation, error)
+	// ListSiteTranslations 는 사이트의 모든 페이지 번역을 page_id, locale 순으로 반환합니다.
+	ListSiteTranslations(ctx context.Context, siteID int) ([]models.PageTranslation, error)
+	// SaveTranslation 은 번역 초안을 저장합니다. 공개 스냅샷은 그대로 둡니다.
+	SaveTranslation(ctx context.Context, translation *models.PageTranslation) error
+	DeleteTranslation(ctx context.Context, pageID int, locale string) error
+
+	// PublishPage 는 페이지의 최신 리비전과 번역 초안을 공개 스냅샷으로 지정합니다.
+	PublishPage(ctx context.Context, pageID int) error
+	// UnpublishPage 는 페이지를 비공개로 바꿉니다. 공개 스냅샷 기록은 남겨 둡니다.
+	UnpublishPage(ctx context.Context, pageID int) error
+
+	// MovePage 는 페이지의 부모와 형제 사이 위치를 바꾸고 depth, menu_order 를 다시 계산합니다.
+	MovePage(ctx context.Context, pageID int, input models.MovePageInput) error
+	// SaveTree 는 그룹의 모든 페이지에 대해 parent_id, depth, menu_order 를 tree 구조대로 저장합니다.
+	SaveTree(ctx context.Context, groupID int, tree []models.TreeNodeInput) error
+}
+
+// APIKeyStore 는 api_keys 테이블에 대한 접근을 추상화합니다. 키 원문은 다루지 않고 해시만 저장, 조회합니다.
+type APIKeyStore interface {
+	CreateAPIKey(ctx context.Context, key *models.APIKey, hash string) (int64, error)
+	ListAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	GetAPIKey(ctx context.Context, keyID int) (*models.APIKey, error)
+	// GetAPIKeyByHash 는 폐기, 만료된 키도 반환합니다. 인증할 수 있는지는 APIKey.Active 로 확인합니다.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	// RevokeAPIKey 는 키를 폐기합니다. 이미 폐기한 키는 처음 폐기한 시각을 유지합니다.
+	RevokeAPIKey(ctx context.Context, keyID int) error
+	// TouchAPIKey 는 키의 마지막 사용 시각을 지금으로 바꿉니다.
+	TouchAPIKey(ctx context.Context, keyID int) error
+}
+
+// AccessStore 는 users, role_grants 테이블에 대한 접근을 추상화합니다.
+type AccessStore interface {
+	ListUsers(ctx context.Context) ([]models.User, error)
+	// GetUser 는 사용자와 그 역할을 반환합니다.
+	GetUser(ctx context.Context, userID int) (*models.User, error)
+	// CreateUser 는 subject 가 이미 있으면 ErrConflict 를 반환합니다.
+	CreateUser(ctx context.Context, input models.CreateUserInput) (int64, error)
+	// DeleteUser 는 사용자와 그 역할을 지웁니다.
+	DeleteUser(ctx context.Context, userID int) error
+	// ListGrantsBySubject 는 subject 사용자의 역할을 반환합니다. 사용자가 없으면 빈 목록입니다.
+	ListGrantsBySubject(ctx context.Context, subject string) ([]models.RoleGrant, error)
+	// CreateGrant 는 사용자에게 역할을 줍니다. 사이트, 그룹이 맞는지는 호출하는 쪽에서 확인하며,
+	// 같은 범위에 같은 역할이 이미 있으면 ErrConflict 를 반환합니다.
+	CreateGrant(ctx context.Context, userID int, role string, siteID, groupID *int) (int64, error)
+	// DeleteGrant 는 사용자 userID 의 역할 grantID 를 지웁니다.
+	DeleteGrant(ctx context.Context, userID, grantID int) error
+}
+
+// Tx 는 Begin 으로 시작한 트랜잭션입니다. 커밋한 뒤의 Rollback 은 아무것도 하지 않습니다.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// AuditStore 는 audit_events 테이블에 대한 접근을 추상화합니다.
+type AuditStore interface {
+	// Begin 은 트랜잭션을 시작해 그 트랜잭션을 담은 컨텍스트를 반환합니다. 변경과 그 이력을 함께 커밋할 때
+	// 쓰며, 이 컨텍스트로 부르는 모든 저장소 메서드가 같은 트랜잭션에서 실행됩니다.
+	Begin(ctx context.Context) (context.Context, Tx, error)
+	// RecordAuditEvent 는 변경 이력을 저장합니다. EventID, CreatedAt 은 무시합니다.
+	RecordAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	// QueryAuditEvents 는 QuerySites 와 같은 방식으로 변경 이력 한 쪽을 반환합니다. siteID 가 0 이 아니면
+	// 그 사이트의 이력만(AuditList), 0 이면 모든 이력(AdminAuditList)을 대상으로 합니다.
+	QueryAuditEvents(ctx context.Context, siteID int, q *listquery.Query) ([]models.AuditEvent, string, error)
+}
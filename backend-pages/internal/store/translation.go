@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"pages/internal/models"
+	"time"
+)
+
+const translationColumns = `t.page_id, t.locale, t.title, t.slug, t.content, t.content_format,
+	t.published_title, t.published_slug, t.published_content, t.published_content_format, t.published_at,
+	t.created_at, t.updated_at`
+
+func scanTranslation(row rowScanner) (*models.PageTranslation, error) {
+	var t models.PageTranslation
+	var pubTitle, pubSlug, pubContent, pubFormat sql.NullString
+	var publishedAt sql.NullTime
+	if err := row.Scan(
+		&t.PageID, &t.Locale, &t.Title, &t.Slug, &t.Content, &t.ContentFormat,
+		&pubTitle, &pubSlug, &pubContent, &pubFormat, &publishedAt,
+		&t.CreatedAt, &t.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if pubTitle.Valid {
+		t.Published = &models.PublishedTranslation{
+			Title:         pubTitle.String,
+			Slug:          pubSlug.String,
+			Content:       pubContent.String,
+			ContentFormat: pubFormat.String,
+			PublishedAt:   publishedAt.Time,
+		}
+	}
+	return &t, nil
+}
+
+func (s *SQLStore) ListTranslations(ctx context.Context, pageID int) ([]models.PageTranslation, error) {
+	return s.queryTranslations(ctx,
+		"SELECT "+translationColumns+" FROM page_translations t WHERE t.page_id = ? ORDER BY t.locale",
+		pageID,
+	)
+}
+
+func (s *SQLStore) ListSiteTranslations(ctx context.Context, siteID int) ([]models.PageTranslation, error) {
+	return s.queryTranslations(ctx,
+		"SELECT "+translationColumns+` FROM page_translations t
+		JOIN pages p ON p.page_id = t.page_id
+		WHERE p.site_id = ?
+		ORDER BY t.page_id, t.locale`,
+		siteID,
+	)
+}
+
+func (s *SQLStore) queryTranslations(ctx context.Context, query string, args ...interface{}) ([]models.PageTranslation, error) {
+	rows, err := s.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []models.PageTranslation{}
+	for rows.Next() {
+		t, err := scanTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, *t)
+	}
+	return translations, rows.Err()
+}
+
+// SaveTranslation 은 공개 스냅샷과 created_at 을 보존해야 하므로 삭제 후 추가하지 않고,
+// 행이 있으면 갱신하고 없으면 추가합니다. 페이지가 없으면 ErrNotFound 를 반환합니다.
+func (s *SQLStore) SaveTranslation(ctx context.Context, t *models.PageTranslation) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := bumpPageVersion(ctx, tx, t.PageID); err != nil {
+			return err
+		}
+
+		var exists int
+		err := tx.QueryRowContext(ctx, "SELECT 1 FROM page_translations WHERE page_id = ? AND locale = ?",
+			t.PageID, t.Locale,
+		).Scan(&exists)
+		if errors.Is(err, sql.ErrNoRows) {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO page_translations (page_id, locale, title, slug, content, content_format)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				t.PageID, t.Locale, t.Title, t.Slug, t.Content, t.ContentFormat,
+			)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE page_translations
+			SET title = ?, slug = ?, content = ?, content_format = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE page_id = ? AND locale = ?
+		`, t.Title, t.Slug, t.Content, t.ContentFormat, t.PageID, t.Locale)
+		return err
+	})
+}
+
+func (s *SQLStore) DeleteTranslation(ctx context.Context, pageID int, locale string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx,
+			"DELETE FROM page_translations WHERE page_id = ? AND locale = ?",
+			pageID, locale,
+		)
+		if err != nil {
+			return err
+		}
+		if err := expectRows(result); err != nil {
+			return err
+		}
+		return bumpPageVersion(ctx, tx, pageID)
+	})
+}
+
+// bumpPageVersion 은 번역이 바뀐 페이지의 버전을 올립니다. 페이지가 없으면 ErrNotFound 를 반환합니다.
+func bumpPageVersion(ctx context.Context, tx *sql.Tx, pageID int) error {
+	result, err := tx.ExecContext(ctx, "UPDATE pages SET version = version + 1 WHERE page_id = ?", pageID)
+	if err != nil {
+		return err
+	}
+	return expectRows(result)
+}
+
+// publishTranslations 는 where 조건에 맞는 페이지의 번역 초안을 공개 스냅샷으로 복사합니다.
+func publishTranslations(ctx context.Context, tx *sql.Tx, where string, args ...interface{}) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE page_translations
+		SET published_title = title, published_slug = slug, published_content = content,
+			published_content_format = content_format, published_at = CURRENT_TIMESTAMP
+		WHERE `+where, args...)
+	return err
+}
+
+func (s *SQLStore) GetSiteLocales(ctx context.Context, siteID int) (*models.SiteLocales, error) {
+	var data string
+	var updatedAt time.Time
+	err := s.conn(ctx).QueryRowContext(ctx,
+		"SELECT settings, updated_at FROM site_locales WHERE site_id = ?",
+		siteID,
+	).Scan(&data, &updatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	var locales models.SiteLocales
+	if err := json.Unmarshal([]byte(data), &locales); err != nil {
+		return nil, err
+	}
+	locales.UpdatedAt = &updatedAt
+	return &locales, nil
+}
+
+// SaveSiteLocales 는 SaveSiteTheme 과 같이 삭제 후 다시 추가합니다.
+func (s *SQLStore) SaveSiteLocales(ctx context.Context, siteID int, locales models.SiteLocales) error {
+	locales.UpdatedAt = nil
+	data, err := json.Marshal(locales)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM site_locales WHERE site_id = ?", siteID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO site_locales (site_id, settings) VALUES (?, ?)",
+			siteID, string(data),
+		)
+		if err != nil {
+			return err
+		}
+		// 언어 설정은 메뉴의 언어 선택에 영향을 주므로 사이트 버전(메뉴 ETag)을 올립니다.
+		_, err = tx.ExecContext(ctx, "UPDATE sites SET version = version + 1 WHERE site_id = ?", siteID)
+		return err
+	})
+}
@@ -0,0 +1,294 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pages/internal/models"
+	"sort"
+)
+
+// treeNode 는 메뉴 트리의 depth, menu_order 계산에 필요한 페이지 정보입니다.
+type treeNode struct {
+	id        int
+	parentID  *int
+	depth     int
+	menuOrder int
+	stored    *int // DB 에 저장된 parent_id
+}
+
+// loadGroupTree 는 그룹에 속한 모든 페이지의 트리 정보를 읽습니다.
+func loadGroupTree(ctx context.Context, tx *sql.Tx, groupID int) (map[int]*treeNode, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT page_id, parent_id, depth, menu_order FROM pages WHERE group_id = ?",
+		groupID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodes := make(map[int]*treeNode)
+	for rows.Next() {
+		var n treeNode
+		if err := rows.Scan(&n.id, &n.parentID, &n.depth, &n.menuOrder); err != nil {
+			return nil, err
+		}
+		n.stored = n.parentID
+		nodes[n.id] = &n
+	}
+	return nodes, rows.Err()
+}
+
+// childrenOf 는 parentID 의 자식 노드를 menu_order 순으로 반환합니다. parentID 가 nil 이면 최상위 노드입니다.
+func childrenOf(nodes map[int]*treeNode, parentID *int) []*treeNode {
+	var children []*treeNode
+	for _, n := range nodes {
+		if sameParent(n.parentID, parentID) {
+			children = append(children, n)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].menuOrder != children[j].menuOrder {
+			return children[i].menuOrder < children[j].menuOrder
+		}
+		return children[i].id < children[j].id
+	})
+	return children
+}
+
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// renumber 는 형제 노드의 menu_order 를 1부터 다시 매기고 바뀐 노드를 changed 에 기록합니다.
+func renumber(siblings []*treeNode, changed map[int]bool) {
+	for i, n := range siblings {
+		if n.menuOrder != i+1 {
+			n.menuOrder = i + 1
+			changed[n.id] = true
+		}
+	}
+}
+
+// updateDepths 는 root 와 그 하위 트리 전체의 depth 를 부모 기준으로 다시 계산합니다.
+func updateDepths(nodes map[int]*treeNode, root *treeNode, changed map[int]bool) {
+	children := make(map[int][]*treeNode)
+	for _, n := range nodes {
+		if n.parentID != nil {
+			children[*n.parentID] = append(children[*n.parentID], n)
+		}
+	}
+
+	depth := 0
+	if root.parentID != nil {
+		if parent, ok := nodes[*root.parentID]; ok {
+			depth = parent.depth + 1
+		}
+	}
+
+	var walk func(n *treeNode, depth int)
+	walk = func(n *treeNode, depth int) {
+		if n.depth != depth {
+			n.depth = depth
+			changed[n.id] = true
+		}
+		for _, child := range children[n.id] {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, depth)
+}
+
+// writeTree 는 changed 에 기록된 노드의 parent_id, depth, menu_order 를 저장합니다.
+func writeTree(ctx context.Context, tx *sql.Tx, nodes map[int]*treeNode, changed map[int]bool) error {
+	// 한 행씩 옮기면 형제끼리 부모를 맞바꾸거나 나중에 비는 자리로 옮길 때 중간 상태가
+	// UNIQUE (site_id, slug, parent_id) 에 걸립니다. 부모가 바뀌는 행은 먼저 parent_id 를 NULL 로
+	// 비워 두고(NULL 끼리는 충돌하지 않습니다) 최종 위치를 씁니다. 그래도 충돌하면 최종 트리의 충돌입니다.
+	if err := detachMoved(ctx, tx, nodes, changed); err != nil {
+		return err
+	}
+	for id := range changed {
+		n := nodes[id]
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE pages SET parent_id = ?, depth = ?, menu_order = ?, version = version + 1 WHERE page_id = ?",
+			n.parentID, n.depth, n.menuOrder, n.id,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// detachMoved 는 changed 중 부모가 바뀌는 행의 parent_id 를 NULL 로 비웁니다.
+func detachMoved(ctx context.Context, tx *sql.Tx, nodes map[int]*treeNode, changed map[int]bool) error {
+	for id := range changed {
+		n := nodes[id]
+		if n.stored == nil || sameParent(n.stored, n.parentID) {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE pages SET parent_id = NULL WHERE page_id = ?", id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// placeNewPage 는 새 페이지의 depth 를 부모 기준으로, menu_order 를 형제 중 마지막으로 정합니다.
+func placeNewPage(ctx context.Context, tx *sql.Tx, page *models.Page) error {
+	page.Depth = 0
+	if page.ParentID != nil {
+		var parentGroupID, parentDepth int
+		err := tx.QueryRowContext(ctx,
+			"SELECT group_id, depth FROM pages WHERE page_id = ?",
+			*page.ParentID,
+		).Scan(&parentGroupID, &parentDepth)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && parentGroupID != page.GroupID) {
+			return ErrInvalidParent
+		} else if err != nil {
+			return err
+		}
+		page.Depth = parentDepth + 1
+	}
+
+	query := "SELECT COALESCE(MAX(menu_order), 0) + 1 FROM pages WHERE group_id = ? AND parent_id IS NULL"
+	args := []interface{}{page.GroupID}
+	if page.ParentID != nil {
+		query = "SELECT COALESCE(MAX(menu_order), 0) + 1 FROM pages WHERE group_id = ? AND parent_id = ?"
+		args = append(args, *page.ParentID)
+	}
+	return tx.QueryRowContext(ctx, query, args...).Scan(&page.MenuOrder)
+}
+
+// MovePage 는 페이지를 input.ParentID 아래 input.BeforeID/AfterID 위치로 옮깁니다.
+// 위치를 지정하지 않으면 형제 중 마지막에 둡니다. 옮긴 뒤 이전/새 형제의 menu_order 와
+// 하위 트리 전체의 depth 를 한 트랜잭션에서 다시 계산합니다.
+func (s *SQLStore) MovePage(ctx context.Context, pageID int, input models.MovePageInput) error {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		return movePage(ctx, tx, pageID, input)
+	})
+	return conflict(err, ErrSlugConflict)
+}
+
+func movePage(ctx context.Context, tx *sql.Tx, pageID int, input models.MovePageInput) error {
+	var groupID int
+	err := tx.QueryRowContext(ctx, "SELECT group_id FROM pages WHERE page_id = ?", pageID).Scan(&groupID)
+	if err != nil {
+		return notFound(err)
+	}
+
+	nodes, err := loadGroupTree(ctx, tx, groupID)
+	if err != nil {
+		return err
+	}
+	page := nodes[pageID]
+
+	if input.ParentID != nil {
+		parent, ok := nodes[*input.ParentID]
+		if !ok {
+			return ErrInvalidParent
+		}
+		// 새 부모에서 최상위까지 올라가며 자기 자신이 있는지 확인
+		for n := parent; n != nil; {
+			if n.id == pageID {
+				return ErrCycle
+			}
+			if n.parentID == nil {
+				break
+			}
+			n = nodes[*n.parentID]
+		}
+	}
+
+	oldParentID := page.parentID
+	page.parentID = input.ParentID
+
+	// 자기 자신을 뺀 새 형제 목록에 위치를 정해 끼워 넣음
+	var siblings []*treeNode
+	for _, n := range childrenOf(nodes, input.ParentID) {
+		if n.id != pageID {
+			siblings = append(siblings, n)
+		}
+	}
+	pos := len(siblings)
+	if input.BeforeID != nil || input.AfterID != nil {
+		target, offset := input.BeforeID, 0
+		if target == nil {
+			target, offset = input.AfterID, 1
+		}
+		pos = -1
+		for i, n := range siblings {
+			if n.id == *target {
+				pos = i + offset
+			}
+		}
+		if pos < 0 {
+			return &ReasonError{Err: ErrInvalidPosition, Reason: "not_child", Args: []interface{}{*target}}
+		}
+	}
+	siblings = append(siblings[:pos], append([]*treeNode{page}, siblings[pos:]...)...)
+
+	changed := map[int]bool{pageID: true}
+	renumber(siblings, changed)
+	if !sameParent(oldParentID, input.ParentID) {
+		renumber(childrenOf(nodes, oldParentID), changed)
+	}
+	updateDepths(nodes, page, changed)
+
+	if err := writeTree(ctx, tx, nodes, changed); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE pages SET updated_at = CURRENT_TIMESTAMP WHERE page_id = ?", pageID)
+	return err
+}
+
+// SaveTree 는 그룹의 메뉴 트리 전체를 tree 구조대로 저장합니다.
+// tree 는 그룹의 모든 페이지를 정확히 한 번씩 포함해야 하며, 각 노드의 parent_id, depth,
+// menu_order 를 한 트랜잭션에서 갱신합니다.
+func (s *SQLStore) SaveTree(ctx context.Context, groupID int, tree []models.TreeNodeInput) error {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		nodes, err := loadGroupTree(ctx, tx, groupID)
+		if err != nil {
+			return err
+		}
+
+		changed := make(map[int]bool)
+		seen := make(map[int]bool)
+		var walk func(children []models.TreeNodeInput, parentID *int, depth int) error
+		walk = func(children []models.TreeNodeInput, parentID *int, depth int) error {
+			for i, child := range children {
+				n, ok := nodes[child.PageID]
+				if !ok {
+					return &ReasonError{Err: ErrInvalidTree, Reason: "unknown_page", Args: []interface{}{child.PageID}}
+				}
+				if seen[n.id] {
+					return &ReasonError{Err: ErrInvalidTree, Reason: "duplicate_page", Args: []interface{}{n.id}}
+				}
+				seen[n.id] = true
+
+				if !sameParent(n.parentID, parentID) || n.depth != depth || n.menuOrder != i+1 {
+					n.parentID, n.depth, n.menuOrder = parentID, depth, i+1
+					changed[n.id] = true
+				}
+
+				id := n.id
+				if err := walk(child.Menu, &id, depth+1); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := walk(tree, nil, 0); err != nil {
+			return err
+		}
+
+		if len(seen) != len(nodes) {
+			return &ReasonError{Err: ErrInvalidTree, Reason: "missing_pages", Args: []interface{}{len(nodes), len(seen)}}
+		}
+		return writeTree(ctx, tx, nodes, changed)
+	})
+	return conflict(err, ErrSlugConflict)
+}
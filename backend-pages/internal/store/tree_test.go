@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"pages/internal/models"
+	"testing"
+)
+
+func intPtr(v int) *int { return &v }
+
+// position 은 페이지의 parent_id(최상위는 0), depth, menu_order 입니다.
+type position struct{ parent, depth, order int }
+
+func positionOf(t *testing.T, s *SQLStore, pageID int) position {
+	t.Helper()
+	page, err := s.GetPage(context.Background(), pageID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := position{depth: page.Depth, order: page.MenuOrder}
+	if page.ParentID != nil {
+		p.parent = *page.ParentID
+	}
+	return p
+}
+
+func expectPositions(t *testing.T, s *SQLStore, want map[int]position) {
+	t.Helper()
+	for id, w := range want {
+		if got := positionOf(t, s, id); got != w {
+			t.Errorf("page %d = %+v, want %+v", id, got, w)
+		}
+	}
+}
+
+func TestMovePage(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	siteID, groupID := testGroup(t, s)
+
+	// a(1) > child(same), b(2) > child(same) > grandchild, c(3)
+	a := testPage(t, s, siteID, groupID, 0, "a")
+	b := testPage(t, s, siteID, groupID, 0, "b")
+	c := testPage(t, s, siteID, groupID, 0, "c")
+	childA := testPage(t, s, siteID, groupID, a, "same")
+	childB := testPage(t, s, siteID, groupID, b, "same")
+	grandchild := testPage(t, s, siteID, groupID, childB, "leaf")
+
+	t.Run("before", func(t *testing.T) {
+		if err := s.MovePage(ctx, c, models.MovePageInput{BeforeID: intPtr(a)}); err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{c: {0, 0, 1}, a: {0, 0, 2}, b: {0, 0, 3}})
+	})
+
+	t.Run("after", func(t *testing.T) {
+		if err := s.MovePage(ctx, c, models.MovePageInput{AfterID: intPtr(b)}); err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{a: {0, 0, 1}, b: {0, 0, 2}, c: {0, 0, 3}})
+	})
+
+	t.Run("position outside new parent", func(t *testing.T) {
+		err := s.MovePage(ctx, c, models.MovePageInput{ParentID: intPtr(a), BeforeID: intPtr(b)})
+		var reason *ReasonError
+		if !errors.Is(err, ErrInvalidPosition) || !errors.As(err, &reason) || reason.Reason != "not_child" {
+			t.Fatalf("err = %v, want ErrInvalidPosition not_child", err)
+		}
+		expectPositions(t, s, map[int]position{c: {0, 0, 3}})
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		for _, parent := range []int{b, grandchild} {
+			if err := s.MovePage(ctx, b, models.MovePageInput{ParentID: intPtr(parent)}); !errors.Is(err, ErrCycle) {
+				t.Errorf("move under %d: err = %v, want ErrCycle", parent, err)
+			}
+		}
+		expectPositions(t, s, map[int]position{b: {0, 0, 2}})
+	})
+
+	t.Run("slug collision", func(t *testing.T) {
+		err := s.MovePage(ctx, childB, models.MovePageInput{ParentID: intPtr(a)})
+		if !errors.Is(err, ErrSlugConflict) {
+			t.Fatalf("err = %v, want ErrSlugConflict", err)
+		}
+		// 트랜잭션이 되돌려져 위치가 그대로여야 합니다.
+		expectPositions(t, s, map[int]position{childA: {a, 1, 1}, childB: {b, 1, 1}, grandchild: {childB, 2, 1}})
+	})
+
+	t.Run("reparent subtree", func(t *testing.T) {
+		if err := s.MovePage(ctx, b, models.MovePageInput{ParentID: intPtr(c)}); err != nil {
+			t.Fatal(err)
+		}
+		// 이전 형제는 다시 번호를 매기고 하위 트리의 depth 도 바뀝니다.
+		expectPositions(t, s, map[int]position{
+			a: {0, 0, 1}, c: {0, 0, 2},
+			b: {c, 1, 1}, childB: {b, 2, 1}, grandchild: {childB, 3, 1},
+		})
+	})
+}
+
+func TestSaveTree(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	siteID, groupID := testGroup(t, s)
+
+	a := testPage(t, s, siteID, groupID, 0, "a")
+	b := testPage(t, s, siteID, groupID, 0, "b")
+	childA := testPage(t, s, siteID, groupID, a, "same")
+	childB := testPage(t, s, siteID, groupID, b, "same")
+
+	node := func(id int, menu ...models.TreeNodeInput) models.TreeNodeInput {
+		return models.TreeNodeInput{PageID: id, Menu: menu}
+	}
+
+	invalid := []struct {
+		name   string
+		tree   []models.TreeNodeInput
+		reason string
+	}{
+		{"unknown page", []models.TreeNodeInput{node(a, node(childA)), node(b, node(childB)), node(9999)}, "unknown_page"},
+		{"duplicate page", []models.TreeNodeInput{node(a, node(childA)), node(b, node(childB, node(a)))}, "duplicate_page"},
+		{"missing pages", []models.TreeNodeInput{node(a, node(childA)), node(b)}, "missing_pages"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.SaveTree(ctx, groupID, tt.tree)
+			var reason *ReasonError
+			if !errors.Is(err, ErrInvalidTree) || !errors.As(err, &reason) || reason.Reason != tt.reason {
+				t.Fatalf("err = %v, want ErrInvalidTree %s", err, tt.reason)
+			}
+		})
+	}
+
+	t.Run("swap same slugs", func(t *testing.T) {
+		// 같은 slug 의 두 페이지가 부모를 맞바꿉니다. 한 행씩 옮기면 중간에 unique 제약에 걸립니다.
+		tree := []models.TreeNodeInput{node(b, node(childA)), node(a, node(childB))}
+		if err := s.SaveTree(ctx, groupID, tree); err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{
+			b: {0, 0, 1}, a: {0, 0, 2}, childA: {b, 1, 1}, childB: {a, 1, 1},
+		})
+	})
+
+	t.Run("slug collision", func(t *testing.T) {
+		tree := []models.TreeNodeInput{node(b, node(childA), node(childB)), node(a)}
+		if err := s.SaveTree(ctx, groupID, tree); !errors.Is(err, ErrSlugConflict) {
+			t.Fatalf("err = %v, want ErrSlugConflict", err)
+		}
+		expectPositions(t, s, map[int]position{childA: {b, 1, 1}, childB: {a, 1, 1}})
+	})
+}
+
+func TestPatchPagePosition(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	siteID, groupID := testGroup(t, s)
+
+	a := testPage(t, s, siteID, groupID, 0, "a")
+	b := testPage(t, s, siteID, groupID, 0, "b")
+	c := testPage(t, s, siteID, groupID, 0, "c")
+	x := testPage(t, s, siteID, groupID, a, "x")
+	y := testPage(t, s, siteID, groupID, a, "y")
+
+	// patch 는 decodePatch 처럼 현재 값으로 채운 입력에 바뀐 필드만 덮어씁니다.
+	patch := func(pageID int, fields map[string]bool, apply func(*models.PatchPageInput)) error {
+		page, err := s.GetPage(ctx, pageID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		input := models.PatchPageInput{
+			Title: page.Title, Slug: page.Slug, ContentFormat: page.ContentFormat,
+			ParentID: page.ParentID, MenuOrder: page.MenuOrder,
+		}
+		apply(&input)
+		return s.PatchPage(ctx, pageID, input, fields, page.Version)
+	}
+
+	t.Run("parent only appends", func(t *testing.T) {
+		// c 의 menu_order(3)가 그대로 남아 있어도 새 부모의 마지막에 둡니다.
+		err := patch(c, map[string]bool{"parent_id": true}, func(in *models.PatchPageInput) { in.ParentID = intPtr(a) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{
+			a: {0, 0, 1}, b: {0, 0, 2}, x: {a, 1, 1}, y: {a, 1, 2}, c: {a, 1, 3},
+		})
+	})
+
+	t.Run("parent and menu_order", func(t *testing.T) {
+		err := patch(c, map[string]bool{"parent_id": true, "menu_order": true}, func(in *models.PatchPageInput) {
+			in.ParentID, in.MenuOrder = intPtr(b), 1
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{x: {a, 1, 1}, y: {a, 1, 2}, c: {b, 1, 1}})
+	})
+
+	t.Run("menu_order only", func(t *testing.T) {
+		err := patch(y, map[string]bool{"menu_order": true}, func(in *models.PatchPageInput) { in.MenuOrder = 1 })
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{y: {a, 1, 1}, x: {a, 1, 2}})
+	})
+
+	t.Run("menu_order past end", func(t *testing.T) {
+		err := patch(y, map[string]bool{"menu_order": true}, func(in *models.PatchPageInput) { in.MenuOrder = 10 })
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{x: {a, 1, 1}, y: {a, 1, 2}})
+	})
+
+	t.Run("parent to top level", func(t *testing.T) {
+		err := patch(x, map[string]bool{"parent_id": true}, func(in *models.PatchPageInput) { in.ParentID = nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectPositions(t, s, map[int]position{a: {0, 0, 1}, b: {0, 0, 2}, x: {0, 0, 3}, y: {a, 1, 1}})
+	})
+}
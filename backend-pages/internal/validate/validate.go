@@ -0,0 +1,247 @@
+// Package validate 는 입력 모델의 `validate` 태그로 값을 검사합니다.
+//
+// 태그는 쉼표로 구분한 규칙 목록입니다. required 가 없으면 빈 값(제로 값)은 나머지 규칙을 건너뜁니다.
+//
+//	required        제로 값이면 안 됨 (문자열은 공백만 있어도 안 됨)
+//	max=N, min=N    문자열은 글자 수, 슬라이스와 맵은 길이, 숫자는 값
+//	maxbytes=N      문자열의 바이트 수 (MySQL TEXT 는 65535 바이트)
+//	oneof=a b c     공백으로 구분한 값 중 하나
+//	slug            소문자, 숫자, 한글 등 문자와 단일 하이픈 (예: pricing, 요금-안내)
+//	sitecode        영문 소문자로 시작하는 영문 소문자, 숫자, 하이픈 2~50자
+//	hostname        포트와 스킴이 없는 호스트 이름 (예: cloud.example.com)
+//
+// 구조체, 구조체 포인터, 구조체 슬라이스 필드는 안쪽까지 검사하며 필드 경로는 menu[0].page_id 처럼 표시합니다.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError 는 필드 하나의 검사 실패입니다. Rule 과 Param 으로 클라이언트가 메시지를 만들 수 있습니다.
+// Message 는 영어 메시지이며, 응답에서는 Errors.Localize 로 요청 언어의 메시지로 바뀝니다.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+
+	unit string // max, min 의 단위 (characters, items)
+}
+
+// Errors 는 검사에 실패한 필드 목록입니다.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fe.Field + ": " + fe.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Localize 는 메시지를 lang 언어(ko, en)로 바꾼 목록을 반환합니다.
+func (e Errors) Localize(lang string) interface{} {
+	localized := make(Errors, len(e))
+	for i, fe := range e {
+		fe.Message = message(fe.Rule, fe.unit, fe.Param, lang)
+		localized[i] = fe
+	}
+	return localized
+}
+
+// Failed 는 핸들러가 직접 검사한 필드 하나의 실패입니다. rule 은 messages 에 있는 규칙이어야 합니다.
+func Failed(field, rule, param string) Errors {
+	return Errors{{Field: field, Rule: rule, Param: param, Message: message(rule, "", param, "en")}}
+}
+
+// messages 는 규칙별 언어별 메시지입니다. %s 는 Param 으로 채우며, max, min 은 단위별로 따로 둡니다.
+// 태그 규칙 밖의 after, future, site, scope, default_locale 은 핸들러가 Failed 로 씁니다.
+var messages = map[string]map[string]string{
+	"required":       {"ko": "필수 항목입니다", "en": "is required"},
+	"max":            {"ko": "%s 이하여야 합니다", "en": "must be at most %s"},
+	"max.characters": {"ko": "%s자 이하여야 합니다", "en": "must be at most %s characters"},
+	"max.items":      {"ko": "%s개 이하여야 합니다", "en": "must be at most %s items"},
+	"min":            {"ko": "%s 이상이어야 합니다", "en": "must be at least %s"},
+	"min.characters": {"ko": "%s자 이상이어야 합니다", "en": "must be at least %s characters"},
+	"min.items":      {"ko": "%s개 이상이어야 합니다", "en": "must be at least %s items"},
+	"maxbytes":       {"ko": "%s바이트 이하여야 합니다", "en": "must be at most %s bytes"},
+	"oneof":          {"ko": "%s 중 하나여야 합니다", "en": "must be one of %s"},
+	"slug":           {"ko": "소문자, 숫자, 한글 등 문자와 단일 하이픈만 쓸 수 있습니다", "en": "must contain only lowercase letters, digits and single hyphens"},
+	"sitecode":       {"ko": "영문 소문자로 시작하는 영문 소문자, 숫자, 하이픈 2~50자여야 합니다", "en": "must be 2-50 lowercase letters, digits or hyphens, starting with a letter"},
+	"hostname":       {"ko": "스킴과 포트가 없는 소문자 호스트 이름이어야 합니다", "en": "must be a lowercase host name without scheme or port"},
+	"after":          {"ko": "%s 보다 뒤여야 합니다", "en": "must be after %s"},
+	"future":         {"ko": "현재보다 뒤의 시각이어야 합니다", "en": "must be in the future"},
+	"site":           {"ko": "%s 사이트의 그룹이어야 합니다", "en": "must be a group of %s"},
+	"scope":          {"ko": "super-admin 은 사이트 없이, site-admin 은 사이트만, 그 밖의 역할은 사이트와 선택적으로 그룹을 지정해야 합니다", "en": "super-admin takes no site, site-admin takes a site only, other roles take a site and optionally a group"},
+	"default_locale": {"ko": "기본 언어는 페이지에서 직접 수정합니다", "en": "the default locale is edited on the page itself"},
+}
+
+// message 는 rule 의 lang 언어 메시지를 만듭니다. 모르는 언어는 영어로 씁니다.
+func message(rule, unit, param, lang string) string {
+	key := rule
+	if unit != "" {
+		key += "." + unit
+	}
+	templates, ok := messages[key]
+	if !ok {
+		panic("validate: no message for rule " + key)
+	}
+	template, ok := templates[lang]
+	if !ok {
+		template = templates["en"]
+	}
+	if rule == "oneof" {
+		param = strings.Join(strings.Fields(param), ", ")
+	}
+	if !strings.Contains(template, "%s") {
+		return template
+	}
+	return fmt.Sprintf(template, param)
+}
+
+var (
+	slugPattern     = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}]+(-[\p{Ll}\p{Lo}\p{N}]+)*$`)
+	siteCodePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,48}[a-z0-9]$`)
+	hostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+)
+
+// Slug 는 s 가 slug 규칙에 맞는지 반환합니다.
+func Slug(s string) bool {
+	return slugPattern.MatchString(s)
+}
+
+// Struct 는 v(구조체, 구조체 포인터나 슬라이스)를 검사합니다. 실패한 필드가 없으면 nil 을, 있으면 Errors 를 반환합니다.
+func Struct(v interface{}) error {
+	var errs Errors
+	walk(reflect.ValueOf(v), "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func walk(v reflect.Value, path string, errs *Errors) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), path, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := jsonName(field)
+			if name == "-" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			if tag := field.Tag.Get("validate"); tag != "" {
+				check(v.Field(i), name, tag, errs)
+			}
+			walk(v.Field(i), name, errs)
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// check 는 필드 하나에 태그의 규칙을 차례로 적용하고, 처음 실패한 규칙 하나만 기록합니다.
+func check(v reflect.Value, field, tag string, errs *Errors) {
+	rules := strings.Split(tag, ",")
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.Value{}
+		} else {
+			v = v.Elem()
+		}
+	}
+	empty := !v.IsValid() || v.IsZero() || (v.Kind() == reflect.String && strings.TrimSpace(v.String()) == "")
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "required" {
+			if empty {
+				*errs = append(*errs, FieldError{Field: field, Rule: name, Message: message(name, "", "", "en")})
+				return
+			}
+			continue
+		}
+		if empty {
+			return
+		}
+		if failed, unit := apply(v, name, param); failed {
+			*errs = append(*errs, FieldError{Field: field, Rule: name, Param: param, Message: message(name, unit, param, "en"), unit: unit})
+			return
+		}
+	}
+}
+
+// apply 는 규칙 하나를 적용하고, 실패했는지와 max, min 메시지의 단위를 반환합니다.
+func apply(v reflect.Value, rule, param string) (bool, string) {
+	switch rule {
+	case "max", "min":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			panic("validate: invalid " + rule + " parameter " + param)
+		}
+		size, unit := length(v)
+		if rule == "max" && size > limit || rule == "min" && size < limit {
+			return true, unit
+		}
+	case "maxbytes":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			panic("validate: invalid maxbytes parameter " + param)
+		}
+		return len(v.String()) > limit, ""
+	case "oneof":
+		for _, option := range strings.Fields(param) {
+			if fmt.Sprint(v.Interface()) == option {
+				return false, ""
+			}
+		}
+		return true, ""
+	case "slug":
+		return !Slug(v.String()), ""
+	case "sitecode":
+		return !siteCodePattern.MatchString(v.String()), ""
+	case "hostname":
+		return len(v.String()) > 253 || !hostnamePattern.MatchString(v.String()), ""
+	default:
+		panic("validate: unknown rule " + rule)
+	}
+	return false, ""
+}
+
+// length 는 max, min 이 비교할 크기와 메시지 단위를 반환합니다.
+func length(v reflect.Value) (int, string) {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), "characters"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len(), "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), ""
+	}
+	panic("validate: max/min on unsupported kind " + v.Kind().String())
+}
@@ -1,11 +1,22 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"pages/internal/auth"
 	"pages/internal/database"
 	"pages/internal/handler"
+	"pages/internal/migrate"
+	"pages/internal/rbac"
+	"pages/internal/scheduler"
+	"pages/internal/search"
+	"pages/internal/store"
+	"pages/pkg/response"
+	"time"
 
 	_ "pages/docs" // swagger docs
 
@@ -22,14 +33,102 @@ const PORT = 3000
 // @description Backend Pages API 서버
 // @host localhost:3000
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Bearer <API 키 또는 JWT>
 func main() {
-	// 데이터베이스 연결
+	// 하위 명령: pages migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 하위 명령: pages render (공개 사이트 렌더링 서버)
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		if err := runRender(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 하위 명령: pages apikey create|list|revoke
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := runAPIKey(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 하위 명령: pages export-static --site <code> --out <dir>
+	if len(os.Args) > 1 && os.Args[1] == "export-static" {
+		if err := runExportStatic(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	serve()
+}
+
+// openDB 는 데이터베이스에 연결하고, DB_AUTO_MIGRATE 가 켜져 있으면 마이그레이션을 적용합니다.
+func openDB() (*sql.DB, error) {
 	db, err := database.NewDB()
 	if err != nil {
-		log.Fatalf("Failed to connect to database:%v", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if database.AutoMigrate() {
+		migrator, err := migrate.New(db, database.Driver())
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to load migrations: %w", err)
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+		for _, m := range applied {
+			log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+		}
+	}
+
+	return db, nil
+}
+
+func serve() {
+	db, err := openDB()
+	if err != nil {
+		log.Fatal(err)
 	}
 	defer db.Close()
 
+	s := store.NewSQLStore(db)
+
+	// JWT 검증 키 (JWT_HS256_SECRET_FILE, JWT_RS256_PUBLIC_KEY_FILE). 없으면 API 키로만 인증합니다.
+	jwt, err := auth.LoadJWTVerifier()
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration:%v", err)
+	}
+	authenticator := auth.New(s, jwt)
+
+	// 발급한 API 키가 없으면 첫 관리 키를 발급합니다 (BOOTSTRAP_API_KEY_FILE 또는 로그).
+	if err := bootstrapAPIKey(s); err != nil {
+		log.Fatal(err)
+	}
+
+	// 예약 공개/만료 스케줄러 (SCHEDULER_INTERVAL, 0 이면 비활성)
+	interval, err := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", "30s"))
+	if err != nil {
+		log.Fatalf("Invalid SCHEDULER_INTERVAL:%v", err)
+	}
+	if interval > 0 {
+		go scheduler.New(s, interval).Run(context.Background())
+	}
+
 	// 라우터 생성
 	r := chi.NewRouter()
 
@@ -38,9 +137,9 @@ func main() {
 	r.Use(middleware.Recoverer)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-API-Key", "If-Match", "If-None-Match"},
+		ExposedHeaders:   []string{"Link", "ETag", "Location"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -52,22 +151,72 @@ func main() {
 		})
 	})
 
-	// API 라우트
+	// 등록되지 않은 경로와 메서드도 API 응답 형식으로 응답합니다.
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		response.Error(w, r, response.NotFound(response.CodeNotFound))
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		response.Error(w, r, response.NewError(http.StatusMethodNotAllowed, response.CodeMethodNotAllowed))
+	})
+
+	// API 라우트. 모든 API 는 API 키나 JWT 로 인증해야 합니다.
 	r.Route("/api", func(r chi.Router) {
-		h := handler.NewHandler(db)
+		r.Use(authenticator.Middleware)
+		r.Use(rbac.Middleware(s))
+		h := handler.NewHandler(s, s, s, s, s, s, search.New(searchBackend(s)))
+
+		r.Get("/search", h.Search)
+
+		r.Get("/me/permissions", h.GetMyPermissions)
+
+		// 관리 API (super-admin: admin 키, admin 클레임이 있는 JWT 나 super-admin 역할)
+		r.Route("/admin", func(r chi.Router) {
+			r.Get("/api-keys", h.ListAPIKeys)
+			r.Post("/api-keys", h.CreateAPIKey)
+			r.Delete("/api-keys/{keyID}", h.RevokeAPIKey)
+			r.Get("/users", h.ListUsers)
+			r.Post("/users", h.CreateUser)
+			r.Get("/users/{userID}", h.GetUser)
+			r.Delete("/users/{userID}", h.DeleteUser)
+			r.Post("/users/{userID}/roles", h.CreateRoleGrant)
+			r.Delete("/users/{userID}/roles/{grantID}", h.DeleteRoleGrant)
+			r.Get("/audit", h.ListAllAuditEvents)
+		})
 
 		// 사이트 관련 라우트
 		r.Route("/sites", func(r chi.Router) {
 			r.Get("/", h.GetSites)
 			r.Post("/", h.CreateSite)
 			r.Route("/{siteCode}", func(r chi.Router) {
+				r.Get("/", h.GetSite)
+				r.Put("/", h.UpdateSite)
+				r.Patch("/", h.PatchSite)
+				r.Delete("/", h.DeleteSite)
+				r.Post("/rename", h.RenameSite)
+				r.Get("/audit", h.ListAuditEvents)
 				r.Get("/menu", h.GetSiteMenu)
+				r.Get("/resolve", h.ResolvePage)
+				r.Get("/search", h.SearchSite)
+				r.Get("/locales", h.GetSiteLocales)
+				r.Put("/locales", h.SaveSiteLocales)
+				r.Get("/translations/missing", h.GetTranslationReport)
+				r.Get("/theme", h.GetSiteTheme)
+				r.Put("/theme", h.SaveSiteTheme)
+				r.Get("/sitemap.xml", h.GetSitemap)
+				r.Get("/robots.txt", h.GetRobots)
+				r.Put("/robots.txt", h.SaveRobots)
+				r.Get("/content-policy", h.GetContentPolicy)
+				r.Put("/content-policy", h.SaveContentPolicy)
+				r.Post("/content-policy/dry-run", h.DryRunContentPolicy)
 				r.Route("/groups", func(r chi.Router) {
 					r.Get("/", h.GetPageGroups)
 					r.Post("/", h.CreatePageGroup)
 					r.Route("/{groupId}", func(r chi.Router) {
+						r.Get("/", h.GetPageGroup)
 						r.Put("/", h.UpdatePageGroup)
+						r.Patch("/", h.PatchPageGroup)
 						r.Delete("/", h.DeletePageGroup)
+						r.Put("/tree", h.SaveTree)
 
 						r.Route("/pages", func(r chi.Router) {
 							r.Get("/", h.ListPages)
@@ -75,7 +224,24 @@ func main() {
 							r.Route("/{pageID}", func(r chi.Router) {
 								r.Get("/", h.GetPage)
 								r.Put("/", h.UpdatePage)
+								r.Patch("/", h.PatchPage)
 								r.Delete("/", h.DeletePage)
+								r.Post("/publish", h.PublishPage)
+								r.Post("/unpublish", h.UnpublishPage)
+								r.Post("/move", h.MovePage)
+
+								r.Route("/translations", func(r chi.Router) {
+									r.Get("/", h.ListTranslations)
+									r.Put("/{locale}", h.SaveTranslation)
+									r.Delete("/{locale}", h.DeleteTranslation)
+								})
+
+								r.Route("/revisions", func(r chi.Router) {
+									r.Get("/", h.ListRevisions)
+									r.Get("/diff", h.DiffRevisions)
+									r.Get("/{rev}", h.GetRevision)
+									r.Post("/{rev}/restore", h.RestoreRevision)
+								})
 							})
 						})
 					})
@@ -105,3 +271,18 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// searchBackend 는 MySQL 이면 FULLTEXT 인덱스를, 그 밖에는 프로세스 내 역색인을 검색 백엔드로 씁니다.
+func searchBackend(s *store.SQLStore) search.Backend {
+	if database.Driver() == "mysql" {
+		return s
+	}
+	return search.NewIndex(s, s)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,185 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Code 는 클라이언트가 오류 종류를 구분하는 데 쓰는 고정된 오류 코드입니다.
+type Code string
+
+const (
+	CodeNotFound            Code = "NOT_FOUND"
+	CodeUnauthorized        Code = "UNAUTHORIZED"
+	CodeForbidden           Code = "FORBIDDEN"
+	CodeMethodNotAllowed    Code = "METHOD_NOT_ALLOWED"
+	CodeInvalidParameter    Code = "INVALID_PARAMETER"
+	CodeInvalidBody         Code = "INVALID_BODY"
+	CodeUnsupportedMedia    Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeValidationFailed    Code = "VALIDATION_FAILED"
+	CodeUnsupportedLocale   Code = "UNSUPPORTED_LOCALE"
+	CodeSiteNotFound        Code = "SITE_NOT_FOUND"
+	CodeGroupNotFound       Code = "GROUP_NOT_FOUND"
+	CodePageNotFound        Code = "PAGE_NOT_FOUND"
+	CodeRevisionNotFound    Code = "REVISION_NOT_FOUND"
+	CodeTranslationNotFound Code = "TRANSLATION_NOT_FOUND"
+	CodeAPIKeyNotFound      Code = "API_KEY_NOT_FOUND"
+	CodeUserNotFound        Code = "USER_NOT_FOUND"
+	CodeRoleGrantNotFound   Code = "ROLE_GRANT_NOT_FOUND"
+	CodeSlugConflict        Code = "SLUG_CONFLICT"
+	CodeConflict            Code = "CONFLICT"
+	CodePreconditionFailed  Code = "PRECONDITION_FAILED"
+	CodeConfirmRequired     Code = "CONFIRMATION_REQUIRED"
+	CodeInvalidParent       Code = "INVALID_PARENT"
+	CodeInvalidPosition     Code = "INVALID_POSITION"
+	CodeInvalidTree         Code = "INVALID_TREE"
+	CodePageCycle           Code = "PAGE_CYCLE"
+	CodeInternal            Code = "INTERNAL_ERROR"
+)
+
+// messages 는 오류 코드별 언어별 메시지입니다. %s 는 Error.Args 로 채웁니다.
+// 메시지에는 번역한 문장만 두고, 다른 패키지가 만든 오류 원문은 details 로 보냅니다.
+var messages = map[Code]map[string]string{
+	CodeNotFound:            {"ko": "요청한 경로를 찾을 수 없습니다", "en": "Route not found"},
+	CodeUnauthorized:        {"ko": "인증 정보가 없거나 올바르지 않습니다", "en": "Missing or invalid credentials"},
+	CodeForbidden:           {"ko": "이 작업을 할 권한이 없습니다", "en": "You do not have permission to perform this action"},
+	CodeMethodNotAllowed:    {"ko": "허용되지 않는 메서드입니다", "en": "Method not allowed"},
+	CodeInvalidParameter:    {"ko": "잘못된 파라미터입니다: %s", "en": "Invalid parameter: %s"},
+	CodeInvalidBody:         {"ko": "요청 본문을 읽을 수 없습니다", "en": "Malformed request body"},
+	CodeUnsupportedMedia:    {"ko": "지원하지 않는 Content-Type 입니다: %s", "en": "Unsupported media type: %s"},
+	CodeValidationFailed:    {"ko": "입력값이 올바르지 않습니다", "en": "Validation failed"},
+	CodeUnsupportedLocale:   {"ko": "사이트가 지원하지 않는 언어입니다: %s", "en": "Unsupported locale: %s"},
+	CodeSiteNotFound:        {"ko": "사이트를 찾을 수 없습니다", "en": "Site not found"},
+	CodeGroupNotFound:       {"ko": "페이지 그룹을 찾을 수 없습니다", "en": "Page group not found"},
+	CodePageNotFound:        {"ko": "페이지를 찾을 수 없습니다", "en": "Page not found"},
+	CodeRevisionNotFound:    {"ko": "리비전을 찾을 수 없습니다", "en": "Revision not found"},
+	CodeTranslationNotFound: {"ko": "번역을 찾을 수 없습니다", "en": "Translation not found"},
+	CodeAPIKeyNotFound:      {"ko": "API 키를 찾을 수 없습니다", "en": "API key not found"},
+	CodeUserNotFound:        {"ko": "사용자를 찾을 수 없습니다", "en": "User not found"},
+	CodeRoleGrantNotFound:   {"ko": "역할을 찾을 수 없습니다", "en": "Role grant not found"},
+	CodeSlugConflict:        {"ko": "같은 위치에 같은 slug 의 페이지가 이미 있습니다", "en": "A page with the same slug already exists at this position"},
+	CodeConflict:            {"ko": "이미 존재하는 값입니다", "en": "The value already exists"},
+	CodePreconditionFailed:  {"ko": "다른 요청이 먼저 수정했습니다. 다시 조회한 뒤 수정하세요", "en": "The resource was modified by another request; fetch it again"},
+	CodeConfirmRequired:     {"ko": "삭제하려면 confirm 파라미터로 사이트 코드를 보내세요: confirm=%s", "en": "Deletion must be confirmed with confirm=%s"},
+	CodeInvalidParent:       {"ko": "부모 페이지를 찾을 수 없습니다", "en": "Parent page not found in this group"},
+	CodeInvalidPosition:     {"ko": "기준 페이지가 새 부모의 하위 페이지가 아닙니다", "en": "Sibling page is not a child of the new parent"},
+	CodeInvalidTree:         {"ko": "메뉴 트리가 그룹의 페이지 구성과 맞지 않습니다", "en": "Menu tree does not match the pages of the group"},
+	CodePageCycle:           {"ko": "페이지를 자기 자신이나 하위 페이지 아래로 옮길 수 없습니다", "en": "A page cannot be moved under itself"},
+	CodeInternal:            {"ko": "서버 오류가 발생했습니다", "en": "Internal server error"},
+}
+
+// defaultLanguage 는 Accept-Language 에 지원하는 언어가 없을 때 쓰는 메시지 언어입니다.
+const defaultLanguage = "ko"
+
+// APIError 는 응답 상태 코드와 오류 코드를 가진 오류입니다. 핸들러는 이 오류를 Error 함수로 응답합니다.
+type APIError struct {
+	Status  int
+	Code    Code
+	Args    []interface{} // 메시지 인자
+	Details interface{}   // 응답의 error.details. Localizer 이면 요청 언어로 바꿔 보냅니다.
+}
+
+// Localizer 는 요청 언어에 맞춰 바꿔 보내는 details 입니다. lang 은 ko 나 en 입니다.
+type Localizer interface {
+	Localize(lang string) interface{}
+}
+
+// Reason 은 고정된 이유 식별자와 번역한 설명을 함께 보내는 details 입니다.
+type Reason struct {
+	Reason string        `json:"reason"`
+	Args   []interface{} `json:"-"`
+}
+
+// reasons 는 Reason 의 언어별 설명입니다. %d 는 Reason.Args 로 채웁니다.
+var reasons = map[string]map[string]string{
+	"unknown_page":   {"ko": "페이지 %d 는 이 그룹에 없습니다", "en": "page %d is not in this group"},
+	"duplicate_page": {"ko": "페이지 %d 가 중복되었습니다", "en": "page %d appears more than once"},
+	"missing_pages":  {"ko": "그룹의 페이지 %d개 중 %d개만 포함되었습니다", "en": "only %[2]d of the %[1]d pages of the group are included"},
+	"not_child":      {"ko": "%d 는 새 부모의 하위 페이지가 아닙니다", "en": "page %d is not a child of the new parent"},
+}
+
+// Localize 는 {"reason", "message"} 형식으로 lang 언어의 설명을 붙여 반환합니다.
+func (d Reason) Localize(lang string) interface{} {
+	templates, ok := reasons[d.Reason]
+	if !ok {
+		return map[string]string{"reason": d.Reason}
+	}
+	template, ok := templates[lang]
+	if !ok {
+		template = templates[defaultLanguage]
+	}
+	return map[string]string{"reason": d.Reason, "message": fmt.Sprintf(template, d.Args...)}
+}
+
+// Cause 는 번역하지 않는 오류 원문입니다. JSON 디코더나 템플릿 파서처럼 다른 패키지가 만든 메시지를 그대로 보냅니다.
+type Cause struct {
+	Error string `json:"error"`
+}
+
+func NewError(status int, code Code, args ...interface{}) *APIError {
+	return &APIError{Status: status, Code: code, Args: args}
+}
+
+func (e *APIError) Error() string {
+	return string(e.Code) + ": " + e.Message("en")
+}
+
+// Message 는 lang 언어의 메시지를 반환합니다.
+func (e *APIError) Message(lang string) string {
+	templates, ok := messages[e.Code]
+	if !ok {
+		return string(e.Code)
+	}
+	template, ok := templates[lang]
+	if !ok {
+		template = templates[defaultLanguage]
+	}
+	if len(e.Args) == 0 {
+		return strings.TrimSuffix(template, ": %s")
+	}
+	return fmt.Sprintf(template, e.Args...)
+}
+
+// InvalidParameter 는 경로나 쿼리 파라미터 name 의 값이 잘못되었을 때의 400 오류입니다.
+func InvalidParameter(name string) *APIError {
+	return NewError(http.StatusBadRequest, CodeInvalidParameter, name)
+}
+
+// InvalidBody 는 요청 본문을 JSON 으로 읽지 못했을 때의 400 오류입니다. 디코더 오류 원문은 details.error 로 보냅니다.
+func InvalidBody(err error) *APIError {
+	apiErr := NewError(http.StatusBadRequest, CodeInvalidBody)
+	apiErr.Details = Cause{Error: err.Error()}
+	return apiErr
+}
+
+// Validation 은 입력값 검사에 실패했을 때의 400 오류입니다. reason 은 details.error 로 보냅니다.
+func Validation(reason string) *APIError {
+	apiErr := NewError(http.StatusBadRequest, CodeValidationFailed)
+	apiErr.Details = Cause{Error: reason}
+	return apiErr
+}
+
+// Invalid 는 필드별 검사 실패 목록(details)과 함께 보내는 400 VALIDATION_FAILED 오류입니다.
+func Invalid(details interface{}) *APIError {
+	err := NewError(http.StatusBadRequest, CodeValidationFailed)
+	err.Details = details
+	return err
+}
+
+// NotFound 는 code 의 404 오류입니다.
+func NotFound(code Code) *APIError {
+	return NewError(http.StatusNotFound, code)
+}
+
+// language 는 Accept-Language 에서 처음으로 나오는, 메시지가 있는 언어를 반환합니다.
+func language(r *http.Request) string {
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if lang == "ko" || lang == "en" {
+			return lang
+		}
+	}
+	return defaultLanguage
+}
@@ -2,29 +2,79 @@ package response
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
 )
 
+// Response 는 모든 API 응답의 공통 형식입니다. 성공하면 Data 를, 실패하면 Error 를 채웁니다.
 type Response struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Error   *ErrorBody  `json:"error,omitempty"`
+	Meta    *Meta       `json:"meta,omitempty"`
+}
+
+// Meta 는 목록 응답의 페이지네이션 정보입니다. NextCursor 가 null 이면 마지막 목록이며,
+// 아니면 같은 요청에 cursor=<NextCursor> 를 붙여 다음 목록을 가져옵니다.
+type Meta struct {
+	NextCursor *string `json:"next_cursor"`
+}
+
+// ErrorBody 는 실패 응답의 오류 정보입니다. Code 는 바뀌지 않는 식별자이고 Message 는 요청 언어로 번역된 설명입니다.
+type ErrorBody struct {
+	Code    Code        `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 func JSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(Response{
+	write(w, status, Response{
 		Success: status >= 200 && status < 300,
 		Data:    data,
 	})
 }
 
-func Error(w http.ResponseWriter, message string, status int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(Response{
+// List 는 목록 한 쪽을 200 으로 보내고 meta 에 다음 커서를 담습니다. next 가 비어 있으면 마지막 목록입니다.
+func List(w http.ResponseWriter, data interface{}, next string) {
+	meta := &Meta{}
+	if next != "" {
+		meta.NextCursor = &next
+	}
+	write(w, http.StatusOK, Response{Success: true, Data: data, Meta: meta})
+}
+
+// Error 는 err 를 실패 응답으로 보냅니다. err 가 *APIError 가 아니면 내부 오류로 보고
+// 원래 메시지는 로그에만 남기고 500 INTERNAL_ERROR 로 응답합니다.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	ErrorData(w, r, err, nil)
+}
+
+// ErrorData 는 Error 와 같지만 실패 응답에도 data 를 함께 보냅니다.
+func ErrorData(w http.ResponseWriter, r *http.Request, err error, data interface{}) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		apiErr = NewError(http.StatusInternalServerError, CodeInternal)
+	}
+	lang := language(r)
+	details := apiErr.Details
+	if localizer, ok := details.(Localizer); ok {
+		details = localizer.Localize(lang)
+	}
+	write(w, apiErr.Status, Response{
 		Success: false,
-		Error:   message,
+		Data:    data,
+		Error: &ErrorBody{
+			Code:    apiErr.Code,
+			Message: apiErr.Message(lang),
+			Details: details,
+		},
 	})
 }
+
+func write(w http.ResponseWriter, status int, body Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"pages/internal/render"
+	"pages/internal/store"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// runRender 는 Host 헤더로 사이트를 찾아 공개 페이지를 HTML 로 제공하는 서버를 실행합니다.
+// 포트는 RENDER_PORT(기본 8080)로 지정합니다.
+func runRender() error {
+	port, err := strconv.Atoi(getEnv("RENDER_PORT", "8080"))
+	if err != nil {
+		return fmt.Errorf("invalid RENDER_PORT: %w", err)
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	s := store.NewSQLStore(db)
+
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+	r.Handle("/*", render.NewServer(s, s))
+
+	log.Printf("Render server starting on port %d", port)
+	return http.ListenAndServe(fmt.Sprintf(":%d", port), r)
+}